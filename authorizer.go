@@ -0,0 +1,92 @@
+package vanguard
+
+import (
+	"context"
+)
+
+// Authorizer asserts that a caller has permission to access the endpoints, with the same semantics as the grpc interceptors.
+// It is meant for integrating vanguard with rpc frameworks other than grpc, see the vanguardconnect and vanguardtwirp packages.
+type Authorizer struct {
+	store Vanguard
+	pf    PermissionsFunc
+	opt   *InterceptorOptions
+}
+
+// NewAuthorizer creates an Authorizer, the arguments are the same as the ones of the Interceptor.
+func NewAuthorizer(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) *Authorizer {
+	return &Authorizer{
+		store: store,
+		pf:    pf,
+		opt:   opt.withDefaults(),
+	}
+}
+
+// Authorize asserts that the caller can call method with req. method is the fully qualified method name, Eg: /package.Service/Method
+//
+// The returned context carries the Decision. The returned error is a grpc status error built by the DenialFunc.
+func (a *Authorizer) Authorize(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	if a.opt.Skip {
+		return ctx, nil
+	}
+
+	d, err := a.opt.check(ctx, a.store, method, req, a.pf)
+	if err != nil {
+		return ctx, err
+	}
+
+	return withDecision(ctx, d), nil
+}
+
+// Stream returns a StreamAuthorizer for a new stream of method, it is nil if the stream is allowed without any checks.
+// clientStreams must be set for client and bidi streaming methods.
+func (a *Authorizer) Stream(ctx context.Context, method string, clientStreams bool) (*StreamAuthorizer, error) {
+	if a.opt.Skip {
+		return nil, nil
+	}
+
+	assert, err := a.opt.lookup(ctx, a.store, method)
+	if err != nil || assert == nil {
+		return nil, err
+	}
+
+	return &StreamAuthorizer{
+		g: streamGuard{
+			method: method,
+			assert: assert,
+			each:   clientStreams,
+			pf:     a.pf,
+			opt:    a.opt,
+		},
+	}, nil
+}
+
+// StreamAuthorizer asserts on the messages received on a single stream. The permissions are retrieved once per stream.
+//
+// For server streaming methods only the first message is checked against the assert,
+// for client and bidi streaming methods every message is checked against the assert_each.
+//
+// It is not safe for concurrent use, except for reading the Decision from the context returned by Context.
+type StreamAuthorizer struct {
+	g   streamGuard
+	ref decisionRef
+}
+
+// Authorize asserts that the caller can send m on the stream.
+// Once a message is denied, the same error is returned for the rest of the stream.
+//
+// The returned context carries the Decision of the last message.
+func (s *StreamAuthorizer) Authorize(ctx context.Context, m interface{}) (context.Context, error) {
+	if err := s.g.check(ctx, m); err != nil {
+		return ctx, err
+	}
+	s.ref.set(s.g.decision)
+
+	return withDecision(ctx, s.g.decision), nil
+}
+
+// Context returns a context that carries the Decision of the last allowed message of the stream, it has none
+// until the first message is allowed. It is meant for frameworks that pass a single context to the handlers
+// of streams, before any message is received.
+func (s *StreamAuthorizer) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, decisionKey{}, &s.ref)
+}
@@ -0,0 +1,199 @@
+package vanguard
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ClientInterceptor is grpc UnaryClientInterceptor that asserts that the caller has permission to access the endpoints
+// before the request is sent. Denied calls fail locally without making the call to the server.
+//
+// pf is called for every call with the context passed to the client, the outgoing metadata is exposed as `md`.
+func ClientInterceptor(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) grpc.UnaryClientInterceptor {
+	opt = opt.withDefaults()
+	if opt.Skip {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, err := opt.check(outgoing(ctx), store, method, req, pf); err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is grpc StreamClientInterceptor that asserts that the caller has permission to access
+// streaming endpoints before the messages are sent.
+//
+// For server streaming methods the assert is evaluated against the request message, the stream is only opened
+// once it is allowed so that denied calls do not reach the server.
+// For client and bidi streaming methods the assert_each is evaluated against every message sent.
+// A denied message is not sent and the stream is canceled.
+//
+// pf is called once per stream, when the first message is sent, with the context the stream was created with.
+func StreamClientInterceptor(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) grpc.StreamClientInterceptor {
+	opt = opt.withDefaults()
+	if opt.Skip {
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+	}
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		assert, err := opt.lookup(ctx, store, method)
+		if err != nil {
+			return nil, err
+		} else if assert == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		cs := &clientStream{
+			streamGuard: streamGuard{
+				method: method,
+				assert: assert,
+				each:   desc.ClientStreams,
+				pf:     pf,
+				opt:    opt,
+			},
+			ctx:    outgoing(ctx),
+			cancel: cancel,
+		}
+
+		open := func() (grpc.ClientStream, error) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		if !desc.ClientStreams {
+			// server streaming methods send a single request, the stream is opened once it is allowed
+			cs.open = open
+			return cs, nil
+		}
+
+		if cs.ClientStream, err = open(); err != nil {
+			cancel()
+			return nil, err
+		}
+
+		return cs, nil
+	}
+}
+
+// outgoing exposes the outgoing metadata to the asserts as `md`
+func outgoing(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return WithMetadata(ctx, md)
+}
+
+// clientStream wraps a grpc.ClientStream and asserts on the sent messages.
+//
+// Unlike the server, the evaluation state is not released to the pool as SendMsg and RecvMsg
+// can be called concurrently.
+type clientStream struct {
+	grpc.ClientStream
+	streamGuard
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// open is set if the stream is opened once the first message is allowed, it is guarded by mu
+	mu   sync.Mutex
+	open func() (grpc.ClientStream, error)
+}
+
+var errNotSent = status.Error(codes.Internal, "vanguard: the request must be sent before using the stream")
+
+// stream returns the underlying stream, opening it if the first message was allowed.
+// It is nil if the stream is not open yet.
+func (s *clientStream) stream(open bool) (grpc.ClientStream, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ClientStream != nil || s.open == nil || !open {
+		return s.ClientStream, nil
+	}
+
+	cs, err := s.open()
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+
+	s.ClientStream, s.open = cs, nil
+	return cs, nil
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	if err := s.check(s.ctx, m); err != nil {
+		s.cancel()
+		return err
+	}
+
+	cs, err := s.stream(true)
+	if err != nil {
+		return err
+	}
+
+	return cs.SendMsg(m)
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	cs, _ := s.stream(false)
+	if cs == nil {
+		return errNotSent
+	}
+
+	if err := cs.RecvMsg(m); err != nil {
+		s.cancel()
+		return err
+	}
+
+	return nil
+}
+
+func (s *clientStream) Header() (metadata.MD, error) {
+	cs, _ := s.stream(false)
+	if cs == nil {
+		return nil, errNotSent
+	}
+
+	return cs.Header()
+}
+
+func (s *clientStream) Trailer() metadata.MD {
+	cs, _ := s.stream(false)
+	if cs == nil {
+		return nil
+	}
+
+	return cs.Trailer()
+}
+
+func (s *clientStream) CloseSend() error {
+	cs, _ := s.stream(false)
+	if cs == nil {
+		// there is nothing to close as the request was never sent
+		return nil
+	}
+
+	return cs.CloseSend()
+}
+
+func (s *clientStream) Context() context.Context {
+	cs, _ := s.stream(false)
+	if cs == nil {
+		return s.ctx
+	}
+
+	return cs.Context()
+}
+
+var _ grpc.ClientStream = (*clientStream)(nil)
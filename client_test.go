@@ -0,0 +1,132 @@
+package vanguard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClientStream struct {
+	grpc.ClientStream
+
+	ctx  context.Context
+	sent int
+}
+
+func (s *fakeClientStream) SendMsg(m interface{}) error {
+	s.sent++
+	return nil
+}
+
+func TestClientInterceptor(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: Editor, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}
+
+	ci := vanguard.ClientInterceptor(store, pf, nil)
+	testcases := []struct {
+		Name   string
+		Parent string
+		Code   codes.Code
+	}{
+		{Name: "Allow", Parent: "/parents/12422", Code: codes.OK},
+		{Name: "Deny", Parent: "/parents/12423", Code: codes.PermissionDenied},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			invoked := false
+			err := ci(context.Background(), Create, &expb.CreateExampleRequest{Parent: tc.Parent}, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				invoked = true
+				return nil
+			})
+
+			if status.Code(err) != tc.Code {
+				t.Fatalf("code mismatch, exp: %v, act: %v", tc.Code, status.Code(err))
+			}
+
+			if invoked != (tc.Code == codes.OK) {
+				t.Fatalf("invoker call mismatch, invoked: %v", invoked)
+			}
+		})
+	}
+
+	t.Run("Stream", func(t *testing.T) {
+		var fs *fakeClientStream
+		sci := vanguard.StreamClientInterceptor(store, pf, nil)
+		cs, err := sci(context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, Import, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			fs = &fakeClientStream{ctx: ctx}
+			return fs, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := cs.SendMsg(&expb.CreateExampleRequest{Parent: "/parents/12422"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := cs.SendMsg(&expb.CreateExampleRequest{Parent: "/parents/12423"}); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied, got: %v", err)
+		}
+
+		if fs.sent != 1 {
+			t.Fatalf("expected only the allowed message to be sent, got: %d", fs.sent)
+		}
+
+		if fs.ctx.Err() == nil {
+			t.Fatalf("expected the stream to be canceled")
+		}
+	})
+
+	t.Run("ServerStream", func(t *testing.T) {
+		for _, tc := range []struct {
+			Name   string
+			Parent string
+			Code   codes.Code
+		}{
+			{Name: "Allow", Parent: "/parents/12422", Code: codes.OK},
+			{Name: "Deny", Parent: "/parents/12423", Code: codes.PermissionDenied},
+		} {
+			var fs *fakeClientStream
+			sci := vanguard.StreamClientInterceptor(store, pf, nil)
+			cs, err := sci(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, Watch, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				fs = &fakeClientStream{ctx: ctx}
+				return fs, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fs != nil {
+				t.Fatalf("%s: expected the stream to be opened only after the request is allowed", tc.Name)
+			}
+
+			if err := cs.SendMsg(&expb.ListExamplesRequest{Parent: tc.Parent}); status.Code(err) != tc.Code {
+				t.Fatalf("%s: code mismatch, exp: %v, act: %v", tc.Name, tc.Code, err)
+			}
+
+			if opened := fs != nil; opened != (tc.Code == codes.OK) {
+				t.Fatalf("%s: stream open mismatch, opened: %v", tc.Name, opened)
+			}
+
+			if fs != nil && fs.sent != 1 {
+				t.Fatalf("%s: expected the request to be sent, got: %d", tc.Name, fs.sent)
+			}
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package vanguard
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types/ref"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// conditions compiles the conditions of the permissions against the request types of the methods.
+// The compiled programs are cached by the request type and the text of the condition. As conditions come
+// from the permissions at runtime, the cache is bounded and the least recently used ones are evicted.
+type conditions struct {
+	decls    []*exprpb.Decl
+	progOpts []cel.ProgramOption
+
+	cache *lruCache
+}
+
+type conditionKey struct {
+	input     protoreflect.FullName
+	condition string
+}
+
+// compiledCondition is the outcome of compiling a condition, errors are cached as well
+type compiledCondition struct {
+	prg cel.Program
+	err error
+}
+
+var errNoActivation = errors.New("vanguard: methods and conditions of permissions can only be evaluated by the interceptors")
+
+// holds reports whether the condition of perm holds for the evaluation carried by u.
+// Permissions without a condition always hold.
+//
+// A non nil error means that the condition could not be evaluated, it is recorded in the evaluation state
+// to be reported by the interceptors. Callers must treat it as a denial.
+func (mf matchFuncs) holds(u ref.Val, perm *pb.Permission) (bool, error) {
+	if perm.Condition == "" {
+		return true, nil
+	}
+
+	pl, ok := u.(*permissionList)
+	if !ok || pl.vars == nil {
+		return false, errNoActivation
+	}
+
+	ok, err := mf.conds.eval(perm.Condition, pl.vars)
+	if err != nil {
+		pl.state.fail(err)
+	}
+
+	return ok, err
+}
+
+func (c *conditions) eval(condition string, vars *activation) (bool, error) {
+	req, ok := vars.R.(proto.Message)
+	if !ok {
+		return false, fmt.Errorf("vanguard: unable to evaluate condition: %q, request is not a proto message: %T", condition, vars.R)
+	}
+
+	prg, err := c.compile(req, condition)
+	if err != nil {
+		return false, err
+	}
+
+	v, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("vanguard: unable to evaluate condition: %q, err: %w", condition, err)
+	}
+
+	allow, ok := v.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("vanguard: condition: %q, evaluated to %T instead of bool", condition, v.Value())
+	}
+
+	return allow, nil
+}
+
+func (c *conditions) compile(req proto.Message, condition string) (cel.Program, error) {
+	key := conditionKey{input: req.ProtoReflect().Descriptor().FullName(), condition: condition}
+	if cc, ok := c.cache.get(key); ok {
+		return cc.(*compiledCondition).prg, cc.(*compiledCondition).err
+	}
+
+	cc := &compiledCondition{}
+	cc.prg, cc.err = c.newProgram(req, condition)
+	c.cache.add(key, cc)
+
+	return cc.prg, cc.err
+}
+
+func (c *conditions) newProgram(req proto.Message, condition string) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Types(
+			(*pb.Permission)(nil),
+			(*pb.Subject)(nil),
+		),
+		cel.Types(
+			req.ProtoReflect().Type().New().Interface(),
+		),
+		cel.Declarations(
+			c.decls...,
+		),
+		cel.Declarations(
+			decls.NewVar(
+				"r",
+				decls.NewObjectType(string(req.ProtoReflect().Descriptor().FullName())),
+			),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("vanguard: unable to create cel env for condition: %q, err: %w", condition, err)
+	}
+
+	ast, iss := env.Compile(condition)
+	if err := iss.Err(); err != nil {
+		return nil, fmt.Errorf("vanguard: unable to compile condition: %q, err: %w", condition, err)
+	}
+
+	if !proto.Equal(ast.ResultType(), decls.Bool) {
+		return nil, fmt.Errorf("vanguard: condition: %q, must evaluate to a bool", condition)
+	}
+
+	prg, err := env.Program(ast, c.progOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("vanguard: unable to create program for condition: %q, err: %w", condition, err)
+	}
+
+	return prg, nil
+}
+
+// lruCache is a cache that holds at most size values, evicting the least recently used one when it is full.
+// A cache with a size less than 1 does not hold any value.
+type lruCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[interface{}]*list.Element
+}
+
+type lruEntry struct {
+	key, value interface{}
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, order: list.New(), items: map[interface{}]*list.Element{}}
+}
+
+func (c *lruCache) get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) add(key, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size < 1 {
+		return
+	}
+
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
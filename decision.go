@@ -0,0 +1,73 @@
+package vanguard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Decision is the outcome of evaluating the assert of a call.
+//
+// It is attached to the context passed to the handler and can be read using DecisionFromContext.
+// Calls to methods without an assert do not have a decision.
+type Decision struct {
+	// Method is the fully qualified method name of the call
+	Method string
+	// Allowed is always true unless the method is evaluated in shadow mode
+	Allowed bool
+	// Permissions are the permissions returned by the PermissionsFunc
+	Permissions []*Permission
+	// Subject is the caller returned by the SubjectFunc
+	Subject *Subject
+	// Matched are the permissions that satisfied the hasAny and hasAll checks of the assert
+	Matched []*Permission
+	// Time is when the assert was evaluated and Duration is how long it took
+	Time     time.Time
+	Duration time.Duration
+}
+
+type decisionKey struct{}
+
+// DecisionFromContext returns the authorization decision of the current call
+func DecisionFromContext(ctx context.Context) (*Decision, bool) {
+	switch d := ctx.Value(decisionKey{}).(type) {
+	case *Decision:
+		return d, true
+	case *decisionRef:
+		return d.get()
+	default:
+		return nil, false
+	}
+}
+
+func withDecision(ctx context.Context, d *Decision) context.Context {
+	if d == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, decisionKey{}, d)
+}
+
+// decisionRef holds the decision of a stream whose context is created before any message is received,
+// it is set once a message is allowed. It is safe for concurrent use.
+type decisionRef struct {
+	mu sync.Mutex
+	d  *Decision
+}
+
+func (r *decisionRef) get() (*Decision, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.d, r.d != nil
+}
+
+func (r *decisionRef) set(d *Decision) {
+	if d == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.d = d
+	r.mu.Unlock()
+}
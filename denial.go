@@ -0,0 +1,73 @@
+package vanguard
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Denial describes why a call was denied
+type Denial struct {
+	// Method is the fully qualified method name of the call
+	Method string
+	// Err is the error returned by the PermissionsFunc, the SubjectFunc or the VariablesFunc,
+	// or the error the assert failed to evaluate with. It is nil if the call was denied by the assert.
+	Err error
+
+	// Level and LevelName are the level required by the last hasAny or hasAll check that failed
+	// and Resources are the resources it was checked against.
+	//
+	// If the check accepts a list of levels, Level is the first of them and LevelName has the names of all of them
+	// separated by |, Eg: EDITOR|OWNER
+	//
+	// They are empty if the call was denied without such a check, for example by DenyByDefault.
+	// If the call was denied because levelOn found no permission on a resource, only Resources is set.
+	Level     int64
+	LevelName string
+	Resources []string
+}
+
+// DenialFunc builds the error that is returned to the caller when a call is denied.
+type DenialFunc func(context.Context, *Denial) error
+
+// DefaultDenial is the DenialFunc used when one is not provided in the InterceptorOptions.
+//
+// Errors returned by the PermissionsFunc or met while evaluating the assert are returned as is if they are grpc status errors,
+// others are replaced with an Unknown error so that internal details are not leaked to the caller.
+//
+// Calls that are denied by the assert return a PermissionDenied error with a google.rpc.ErrorInfo detail.
+// Its metadata has the method and, if available, the required level and resources of the check that failed.
+// The level is left out if it is not known, as for the denials of levelOn.
+func DefaultDenial(_ context.Context, d *Denial) error {
+	if d.Err != nil {
+		if _, ok := status.FromError(d.Err); ok {
+			return d.Err
+		}
+		return status.Error(codes.Unknown, "Unknown error")
+	}
+
+	md := map[string]string{"method": d.Method}
+	if len(d.Resources) > 0 {
+		if d.LevelName != "" {
+			md["level"] = d.LevelName
+		} else if d.Level != 0 {
+			md["level"] = strconv.FormatInt(d.Level, 10)
+		}
+		md["resources"] = strings.Join(d.Resources, ",")
+	}
+
+	st := status.New(codes.PermissionDenied, codes.PermissionDenied.String())
+	if ds, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "PERMISSION_DENIED",
+		Domain:   "vanguard",
+		Metadata: md,
+	}); err == nil {
+		st = ds
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.1
+// source: example/admin.proto
+
+package expb
+
+import (
+	_ "github.com/srikrsna/vanguard/vanguard"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_example_admin_proto protoreflect.FileDescriptor
+
+var file_example_admin_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x1a, 0x17,
+	0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72,
+	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xb9, 0x02, 0x0a, 0x13,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x50, 0x75, 0x72, 0x67, 0x65, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x0e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a,
+	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x1e, 0xaa, 0xe6,
+	0xf5, 0x0a, 0x19, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28, 0x4f, 0x57, 0x4e, 0x45,
+	0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x12, 0x57, 0x0a, 0x11,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x05,
+	0xb8, 0xe6, 0xf5, 0x0a, 0x01, 0x1a, 0x20, 0xaa, 0xe6, 0xf5, 0x0a, 0x1b, 0x75, 0x2e, 0x68, 0x61,
+	0x73, 0x41, 0x6e, 0x79, 0x28, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x27, 0x2f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x27, 0x5d, 0x29, 0x42, 0x4c, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6b, 0x72, 0x73, 0x6e, 0x61, 0x2f, 0x76,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x3b,
+	0x65, 0x78, 0x70, 0x62, 0xaa, 0xe6, 0xf5, 0x0a, 0x1c, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e,
+	0x79, 0x28, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x27, 0x2f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x27, 0x5d, 0x29, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_example_admin_proto_goTypes = []interface{}{
+	(*ListExamplesRequest)(nil),  // 0: example.ListExamplesRequest
+	(*GetExampleRequest)(nil),    // 1: example.GetExampleRequest
+	(*emptypb.Empty)(nil),        // 2: google.protobuf.Empty
+	(*Example)(nil),              // 3: example.Example
+	(*ListExamplesResponse)(nil), // 4: example.ListExamplesResponse
+}
+var file_example_admin_proto_depIdxs = []int32{
+	0, // 0: example.ExampleAdminService.PurgeExamples:input_type -> example.ListExamplesRequest
+	1, // 1: example.ExampleAdminService.RestoreExample:input_type -> example.GetExampleRequest
+	0, // 2: example.ExampleAdminService.ListAdminExamples:input_type -> example.ListExamplesRequest
+	2, // 3: example.ExampleAdminService.PurgeExamples:output_type -> google.protobuf.Empty
+	3, // 4: example.ExampleAdminService.RestoreExample:output_type -> example.Example
+	4, // 5: example.ExampleAdminService.ListAdminExamples:output_type -> example.ListExamplesResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_example_admin_proto_init() }
+func file_example_admin_proto_init() {
+	if File_example_admin_proto != nil {
+		return
+	}
+	file_example_example_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_example_admin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_example_admin_proto_goTypes,
+		DependencyIndexes: file_example_admin_proto_depIdxs,
+	}.Build()
+	File_example_admin_proto = out.File
+	file_example_admin_proto_rawDesc = nil
+	file_example_admin_proto_goTypes = nil
+	file_example_admin_proto_depIdxs = nil
+}
@@ -8,6 +8,7 @@ package expb
 
 import (
 	_ "github.com/srikrsna/vanguard/vanguard"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -23,12 +24,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	Visibility_PRIVATE                Visibility = 1
+	Visibility_PUBLIC                 Visibility = 2
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "PRIVATE",
+		2: "PUBLIC",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"PRIVATE":                1,
+		"PUBLIC":                 2,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_example_example_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_example_example_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_example_example_proto_rawDescGZIP(), []int{0}
+}
+
 type Example struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name       string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Visibility Visibility `protobuf:"varint,2,opt,name=visibility,proto3,enum=example.Visibility" json:"visibility,omitempty"`
 }
 
 func (x *Example) Reset() {
@@ -70,6 +121,13 @@ func (x *Example) GetName() string {
 	return ""
 }
 
+func (x *Example) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
 type ListExamplesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -244,6 +302,70 @@ func (x *GetExampleRequest) GetName() string {
 	return ""
 }
 
+type GetExampleRevisionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ids of the parent, the example and the revision requested.
+	Parent   string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Example  string `protobuf:"bytes,2,opt,name=example,proto3" json:"example,omitempty"`
+	Revision string `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *GetExampleRevisionRequest) Reset() {
+	*x = GetExampleRevisionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_example_example_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExampleRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExampleRevisionRequest) ProtoMessage() {}
+
+func (x *GetExampleRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_example_example_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExampleRevisionRequest.ProtoReflect.Descriptor instead.
+func (*GetExampleRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_example_example_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetExampleRevisionRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *GetExampleRevisionRequest) GetExample() string {
+	if x != nil {
+		return x.Example
+	}
+	return ""
+}
+
+func (x *GetExampleRevisionRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
 type CreateExampleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -261,7 +383,7 @@ type CreateExampleRequest struct {
 func (x *CreateExampleRequest) Reset() {
 	*x = CreateExampleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_example_example_proto_msgTypes[4]
+		mi := &file_example_example_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -274,7 +396,7 @@ func (x *CreateExampleRequest) String() string {
 func (*CreateExampleRequest) ProtoMessage() {}
 
 func (x *CreateExampleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_example_example_proto_msgTypes[4]
+	mi := &file_example_example_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +409,7 @@ func (x *CreateExampleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateExampleRequest.ProtoReflect.Descriptor instead.
 func (*CreateExampleRequest) Descriptor() ([]byte, []int) {
-	return file_example_example_proto_rawDescGZIP(), []int{4}
+	return file_example_example_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateExampleRequest) GetParent() string {
@@ -327,7 +449,7 @@ type UpdateExampleRequest struct {
 func (x *UpdateExampleRequest) Reset() {
 	*x = UpdateExampleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_example_example_proto_msgTypes[5]
+		mi := &file_example_example_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -340,7 +462,7 @@ func (x *UpdateExampleRequest) String() string {
 func (*UpdateExampleRequest) ProtoMessage() {}
 
 func (x *UpdateExampleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_example_example_proto_msgTypes[5]
+	mi := &file_example_example_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +475,7 @@ func (x *UpdateExampleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateExampleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateExampleRequest) Descriptor() ([]byte, []int) {
-	return file_example_example_proto_rawDescGZIP(), []int{5}
+	return file_example_example_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateExampleRequest) GetExample() *Example {
@@ -382,7 +504,7 @@ type DeleteExampleRequest struct {
 func (x *DeleteExampleRequest) Reset() {
 	*x = DeleteExampleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_example_example_proto_msgTypes[6]
+		mi := &file_example_example_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -395,7 +517,7 @@ func (x *DeleteExampleRequest) String() string {
 func (*DeleteExampleRequest) ProtoMessage() {}
 
 func (x *DeleteExampleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_example_example_proto_msgTypes[6]
+	mi := &file_example_example_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,7 +530,7 @@ func (x *DeleteExampleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteExampleRequest.ProtoReflect.Descriptor instead.
 func (*DeleteExampleRequest) Descriptor() ([]byte, []int) {
-	return file_example_example_proto_rawDescGZIP(), []int{6}
+	return file_example_example_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteExampleRequest) GetName() string {
@@ -424,86 +546,220 @@ var file_example_example_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
 	0x1a, 0x17, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75,
-	0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
-	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1d, 0x0a, 0x07, 0x45, 0x78, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x69, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x45,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x22, 0x6c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x08,
-	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x79, 0x0a, 0x14, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x52, 0x0a, 0x07, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x69, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x65, 0x78, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x22, 0x7f, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07,
-	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
-	0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x2a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x32, 0xb1, 0x04, 0x0a, 0x0e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x2e, 0xaa, 0xe6, 0xf5, 0x0a, 0x29, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79,
-	0x28, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65,
-	0x6e, 0x74, 0x2b, 0x27, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x27, 0x5d,
-	0x29, 0x12, 0x5b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12,
+	0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x08, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x52, 0x08, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x69, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x79, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x22, 0x7f, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d,
+	0x61, 0x73, 0x6b, 0x22, 0x2a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a,
+	0x41, 0x0a, 0x0a, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a,
+	0x16, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49,
+	0x56, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43,
+	0x10, 0x02, 0x32, 0xcf, 0x12, 0x0a, 0x0e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa2, 0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x55, 0xaa, 0xe6, 0xf5, 0x0a, 0x29, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41,
+	0x6e, 0x79, 0x28, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x2b, 0x27, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f,
+	0x27, 0x5d, 0x29, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x12, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x7b,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x3d, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x2a,
+	0x7d, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x71, 0x0a, 0x0d, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x2e, 0xaa, 0xe6, 0xf5,
+	0x0a, 0x29, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28, 0x56, 0x49, 0x45, 0x57, 0x45,
+	0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x2b, 0x27, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x27, 0x5d, 0x29, 0x30, 0x01, 0x12, 0x58, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x45, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x05, 0xb8, 0xe6, 0xf5, 0x0a, 0x01, 0x12, 0x98, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x49, 0xaa, 0xe6, 0xf5, 0x0a, 0x44, 0x27, 0x78,
+	0x2d, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x2d, 0x69, 0x64, 0x27, 0x20, 0x69, 0x6e, 0x20, 0x6d,
+	0x64, 0x20, 0x26, 0x26, 0x20, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x3d, 0x3d,
+	0x20, 0x27, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x2f, 0x27, 0x20, 0x2b, 0x20, 0x6d, 0x64,
+	0x5b, 0x27, 0x78, 0x2d, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x2d, 0x69, 0x64, 0x27, 0x5d, 0x5b,
+	0x30, 0x5d, 0x12, 0xaa, 0x01, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5d, 0xaa, 0xe6, 0xf5, 0x0a, 0x58, 0x70, 0x65, 0x65, 0x72, 0x2e, 0x73, 0x70, 0x69, 0x66,
+	0x66, 0x65, 0x49, 0x64, 0x20, 0x3d, 0x3d, 0x20, 0x27, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x3a,
+	0x2f, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x73, 0x79,
+	0x6e, 0x63, 0x27, 0x20, 0x7c, 0x7c, 0x20, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28,
+	0x4f, 0x57, 0x4e, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x2b, 0x27, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x27, 0x5d, 0x29, 0x12,
+	0x82, 0x01, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a,
+	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x46, 0xaa, 0xe6,
+	0xf5, 0x0a, 0x1a, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6c, 0x6c, 0x28, 0x56, 0x49, 0x45, 0x57,
+	0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x21, 0x12, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x2a, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x2f, 0x2a, 0x7d, 0x12, 0x8a, 0x01, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x48, 0xaa, 0xe6, 0xf5, 0x0a, 0x13, 0x63, 0x61,
+	0x6e, 0x45, 0x64, 0x69, 0x74, 0x49, 0x6e, 0x28, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x29, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x3a, 0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x22, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x3d, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x2a, 0x7d, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x12, 0x63, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x18, 0xb2, 0xe6, 0xf5, 0x0a,
+	0x13, 0x63, 0x61, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x49, 0x6e, 0x28, 0x72, 0x2e, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x29, 0x28, 0x01, 0x12, 0xcb, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e,
+	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x22, 0x7f, 0xaa, 0xe6, 0xf5, 0x0a, 0x7a, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41,
+	0x6e, 0x79, 0x28, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x28, 0x27, 0x2f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x7b,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x7d, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x2f, 0x7b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x7d, 0x2f, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x7d, 0x27,
+	0x2c, 0x20, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x2c, 0x20, 0x72, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2c, 0x20, 0x72, 0x2e, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x29, 0x5d, 0x29, 0x12, 0x68, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x22, 0x28, 0xaa, 0xe6, 0xf5, 0x0a, 0x23, 0x75, 0x2e, 0x68, 0x61, 0x73,
+	0x41, 0x6c, 0x6c, 0x28, 0x5b, 0x45, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x2c, 0x20, 0x4f, 0x57, 0x4e,
+	0x45, 0x52, 0x5d, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x12, 0x93,
+	0x01, 0x0a, 0x0c, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12,
 	0x1a, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61,
 	0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x1f, 0xaa,
-	0xe6, 0xf5, 0x0a, 0x1a, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6c, 0x6c, 0x28, 0x56, 0x49, 0x45,
-	0x57, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x12, 0x70,
-	0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12,
-	0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
-	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x22, 0x2e, 0xaa, 0xe6, 0xf5, 0x0a, 0x29, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28,
-	0x45, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x2b, 0x27, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x27, 0x5d, 0x29,
-	0x12, 0x69, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x22, 0x27, 0xaa, 0xe6, 0xf5, 0x0a, 0x22, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e,
-	0x79, 0x28, 0x45, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x65, 0x78, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x12, 0x68, 0x0a, 0x0d, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x65,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x20, 0xaa, 0xe6, 0xf5, 0x0a, 0x1b, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41,
-	0x6e, 0x79, 0x28, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e,
-	0x61, 0x6d, 0x65, 0x5d, 0x29, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6b, 0x72, 0x73, 0x6e, 0x61, 0x2f, 0x76, 0x61, 0x6e,
-	0x67, 0x75, 0x61, 0x72, 0x64, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x3b, 0x65, 0x78,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x55, 0xaa,
+	0xe6, 0xf5, 0x0a, 0x50, 0x27, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x73, 0x27, 0x20, 0x69, 0x6e, 0x20,
+	0x73, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x20, 0x7c, 0x7c, 0x20, 0x28, 0x73, 0x2e, 0x69,
+	0x64, 0x20, 0x21, 0x3d, 0x20, 0x27, 0x27, 0x20, 0x26, 0x26, 0x20, 0x72, 0x2e, 0x6e, 0x61, 0x6d,
+	0x65, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x28, 0x27, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x2f, 0x27, 0x20, 0x2b, 0x20, 0x73, 0x2e, 0x69, 0x64, 0x20, 0x2b, 0x20, 0x27,
+	0x2f, 0x27, 0x29, 0x29, 0x12, 0xff, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0xbc, 0x01, 0xaa, 0xe6, 0xf5, 0x0a, 0x7f, 0x75,
+	0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28, 0x45, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x2c, 0x20,
+	0x5b, 0x72, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d,
+	0x29, 0x20, 0x26, 0x26, 0x20, 0x28, 0x72, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x20, 0x21, 0x3d, 0x20, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x2e, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x20, 0x7c, 0x7c, 0x20, 0x75, 0x2e, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x4f, 0x6e, 0x28, 0x72, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x6e,
+	0x61, 0x6d, 0x65, 0x29, 0x20, 0x3d, 0x3d, 0x20, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x29, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x32, 0x32, 0x27, 0x2f, 0x76, 0x31, 0x2f, 0x7b, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x2a, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x2a, 0x7d, 0x3a, 0x07, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x0c, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x4c, 0xaa, 0xe6, 0xf5, 0x0a, 0x1a, 0x75, 0x2e, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x4f, 0x6e, 0x28, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x29, 0x20, 0x3d, 0x3d,
+	0x20, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x12, 0x25, 0x2f, 0x76,
+	0x31, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x2a, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x2a, 0x7d, 0x3a, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x0e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x58, 0xaa,
+	0xe6, 0xf5, 0x0a, 0x53, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28, 0x4d, 0x41, 0x4e,
+	0x41, 0x47, 0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x20,
+	0x26, 0x26, 0x20, 0x6e, 0x6f, 0x77, 0x2e, 0x67, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x28,
+	0x27, 0x55, 0x54, 0x43, 0x27, 0x29, 0x20, 0x3e, 0x3d, 0x20, 0x39, 0x20, 0x26, 0x26, 0x20, 0x6e,
+	0x6f, 0x77, 0x2e, 0x67, 0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x28, 0x27, 0x55, 0x54, 0x43,
+	0x27, 0x29, 0x20, 0x3c, 0x20, 0x31, 0x37, 0x12, 0x79, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x2f, 0xaa, 0xe6, 0xf5, 0x0a, 0x2a, 0x72, 0x2e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x20, 0x2b, 0x20, 0x27, 0x2f, 0x2a, 0x2a, 0x27, 0x20, 0x69, 0x6e, 0x20, 0x75, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x28, 0x4f, 0x57, 0x4e, 0x45,
+	0x52, 0x29, 0x12, 0x8f, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x47, 0xaa, 0xe6, 0xf5,
+	0x0a, 0x1b, 0x75, 0x2e, 0x68, 0x61, 0x73, 0x41, 0x6e, 0x79, 0x28, 0x4d, 0x41, 0x4e, 0x41, 0x47,
+	0x45, 0x52, 0x2c, 0x20, 0x5b, 0x72, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x29, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x21, 0x2a, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x3d, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x2a, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x2f, 0x2a, 0x7d, 0x42, 0x6e, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6b, 0x72, 0x73, 0x6e, 0x61, 0x2f, 0x76, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x72, 0x64, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x3b, 0x65, 0x78, 0x70,
+	0x62, 0xb2, 0xe6, 0xf5, 0x0a, 0x3e, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x49,
+	0x6e, 0x12, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x1a, 0x29, 0x75, 0x2e, 0x68, 0x61, 0x73,
+	0x41, 0x6e, 0x79, 0x28, 0x45, 0x44, 0x49, 0x54, 0x4f, 0x52, 0x2c, 0x20, 0x5b, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x20, 0x2b, 0x20, 0x27, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x2f, 0x27, 0x5d, 0x29, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -518,38 +774,64 @@ func file_example_example_proto_rawDescGZIP() []byte {
 	return file_example_example_proto_rawDescData
 }
 
-var file_example_example_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_example_example_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_example_example_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_example_example_proto_goTypes = []interface{}{
-	(*Example)(nil),               // 0: example.Example
-	(*ListExamplesRequest)(nil),   // 1: example.ListExamplesRequest
-	(*ListExamplesResponse)(nil),  // 2: example.ListExamplesResponse
-	(*GetExampleRequest)(nil),     // 3: example.GetExampleRequest
-	(*CreateExampleRequest)(nil),  // 4: example.CreateExampleRequest
-	(*UpdateExampleRequest)(nil),  // 5: example.UpdateExampleRequest
-	(*DeleteExampleRequest)(nil),  // 6: example.DeleteExampleRequest
-	(*fieldmaskpb.FieldMask)(nil), // 7: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+	(Visibility)(0),                   // 0: example.Visibility
+	(*Example)(nil),                   // 1: example.Example
+	(*ListExamplesRequest)(nil),       // 2: example.ListExamplesRequest
+	(*ListExamplesResponse)(nil),      // 3: example.ListExamplesResponse
+	(*GetExampleRequest)(nil),         // 4: example.GetExampleRequest
+	(*GetExampleRevisionRequest)(nil), // 5: example.GetExampleRevisionRequest
+	(*CreateExampleRequest)(nil),      // 6: example.CreateExampleRequest
+	(*UpdateExampleRequest)(nil),      // 7: example.UpdateExampleRequest
+	(*DeleteExampleRequest)(nil),      // 8: example.DeleteExampleRequest
+	(*fieldmaskpb.FieldMask)(nil),     // 9: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),             // 10: google.protobuf.Empty
 }
 var file_example_example_proto_depIdxs = []int32{
-	0, // 0: example.ListExamplesResponse.examples:type_name -> example.Example
-	0, // 1: example.CreateExampleRequest.example:type_name -> example.Example
-	0, // 2: example.UpdateExampleRequest.example:type_name -> example.Example
-	7, // 3: example.UpdateExampleRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1, // 4: example.ExampleService.ListExamples:input_type -> example.ListExamplesRequest
-	3, // 5: example.ExampleService.GetExample:input_type -> example.GetExampleRequest
-	4, // 6: example.ExampleService.CreateExample:input_type -> example.CreateExampleRequest
-	5, // 7: example.ExampleService.UpdateExample:input_type -> example.UpdateExampleRequest
-	6, // 8: example.ExampleService.DeleteExample:input_type -> example.DeleteExampleRequest
-	2, // 9: example.ExampleService.ListExamples:output_type -> example.ListExamplesResponse
-	0, // 10: example.ExampleService.GetExample:output_type -> example.Example
-	0, // 11: example.ExampleService.CreateExample:output_type -> example.Example
-	0, // 12: example.ExampleService.UpdateExample:output_type -> example.Example
-	8, // 13: example.ExampleService.DeleteExample:output_type -> google.protobuf.Empty
-	9, // [9:14] is the sub-list for method output_type
-	4, // [4:9] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: example.Example.visibility:type_name -> example.Visibility
+	1,  // 1: example.ListExamplesResponse.examples:type_name -> example.Example
+	1,  // 2: example.CreateExampleRequest.example:type_name -> example.Example
+	1,  // 3: example.UpdateExampleRequest.example:type_name -> example.Example
+	9,  // 4: example.UpdateExampleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 5: example.ExampleService.ListExamples:input_type -> example.ListExamplesRequest
+	2,  // 6: example.ExampleService.WatchExamples:input_type -> example.ListExamplesRequest
+	2,  // 7: example.ExampleService.ListPublicExamples:input_type -> example.ListExamplesRequest
+	2,  // 8: example.ExampleService.SearchExamples:input_type -> example.ListExamplesRequest
+	2,  // 9: example.ExampleService.SyncExamples:input_type -> example.ListExamplesRequest
+	4,  // 10: example.ExampleService.GetExample:input_type -> example.GetExampleRequest
+	6,  // 11: example.ExampleService.CreateExample:input_type -> example.CreateExampleRequest
+	6,  // 12: example.ExampleService.ImportExamples:input_type -> example.CreateExampleRequest
+	5,  // 13: example.ExampleService.GetExampleRevision:input_type -> example.GetExampleRevisionRequest
+	4,  // 14: example.ExampleService.PublishExample:input_type -> example.GetExampleRequest
+	4,  // 15: example.ExampleService.ClaimExample:input_type -> example.GetExampleRequest
+	7,  // 16: example.ExampleService.UpdateExample:input_type -> example.UpdateExampleRequest
+	4,  // 17: example.ExampleService.ShareExample:input_type -> example.GetExampleRequest
+	8,  // 18: example.ExampleService.ArchiveExample:input_type -> example.DeleteExampleRequest
+	2,  // 19: example.ExampleService.TransferExamples:input_type -> example.ListExamplesRequest
+	8,  // 20: example.ExampleService.DeleteExample:input_type -> example.DeleteExampleRequest
+	3,  // 21: example.ExampleService.ListExamples:output_type -> example.ListExamplesResponse
+	1,  // 22: example.ExampleService.WatchExamples:output_type -> example.Example
+	3,  // 23: example.ExampleService.ListPublicExamples:output_type -> example.ListExamplesResponse
+	3,  // 24: example.ExampleService.SearchExamples:output_type -> example.ListExamplesResponse
+	3,  // 25: example.ExampleService.SyncExamples:output_type -> example.ListExamplesResponse
+	1,  // 26: example.ExampleService.GetExample:output_type -> example.Example
+	1,  // 27: example.ExampleService.CreateExample:output_type -> example.Example
+	10, // 28: example.ExampleService.ImportExamples:output_type -> google.protobuf.Empty
+	1,  // 29: example.ExampleService.GetExampleRevision:output_type -> example.Example
+	1,  // 30: example.ExampleService.PublishExample:output_type -> example.Example
+	1,  // 31: example.ExampleService.ClaimExample:output_type -> example.Example
+	1,  // 32: example.ExampleService.UpdateExample:output_type -> example.Example
+	1,  // 33: example.ExampleService.ShareExample:output_type -> example.Example
+	10, // 34: example.ExampleService.ArchiveExample:output_type -> google.protobuf.Empty
+	10, // 35: example.ExampleService.TransferExamples:output_type -> google.protobuf.Empty
+	10, // 36: example.ExampleService.DeleteExample:output_type -> google.protobuf.Empty
+	21, // [21:37] is the sub-list for method output_type
+	5,  // [5:21] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_example_example_proto_init() }
@@ -607,7 +889,7 @@ func file_example_example_proto_init() {
 			}
 		}
 		file_example_example_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateExampleRequest); i {
+			switch v := v.(*GetExampleRevisionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -619,7 +901,7 @@ func file_example_example_proto_init() {
 			}
 		}
 		file_example_example_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateExampleRequest); i {
+			switch v := v.(*CreateExampleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -631,6 +913,18 @@ func file_example_example_proto_init() {
 			}
 		}
 		file_example_example_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateExampleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_example_example_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteExampleRequest); i {
 			case 0:
 				return &v.state
@@ -648,13 +942,14 @@ func file_example_example_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_example_example_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_example_example_proto_goTypes,
 		DependencyIndexes: file_example_example_proto_depIdxs,
+		EnumInfos:         file_example_example_proto_enumTypes,
 		MessageInfos:      file_example_example_proto_msgTypes,
 	}.Build()
 	File_example_example_proto = out.File
@@ -0,0 +1,136 @@
+package extpb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	"github.com/srikrsna/vanguard"
+	extpb "github.com/srikrsna/vanguard/example/extension"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	GetProject     = "/example.extension.ProjectService/GetProject"
+	PublishProject = "/example.extension.ProjectService/PublishProject"
+)
+
+func newVanguard(t *testing.T) vanguard.Vanguard {
+	if _, err := vanguard.NewVanguard(); err == nil {
+		t.Fatalf("expected an error without the declarations")
+	}
+
+	store, err := vanguard.NewVanguard(
+		vanguard.WithLevelMatcher(&vanguard.ExactLevelMatcher{}),
+		vanguard.WithLevelSets([]vanguard.LevelSet{
+			{Name: "PUBLISHERS", Values: []int64{vanguard.LevelOwner, vanguard.LevelEditor}},
+		}),
+		vanguard.WithDeclarations(
+			decls.NewFunction("isBusinessHours",
+				decls.NewOverload("is_business_hours_timestamp", []*exprpb.Type{decls.Timestamp}, decls.Bool),
+			),
+			decls.NewVar("cost_center", decls.String),
+		),
+		vanguard.WithFunctions(&functions.Overload{
+			Operator: "is_business_hours_timestamp",
+			Unary: func(v ref.Val) ref.Val {
+				ts, ok := v.(types.Timestamp)
+				if !ok {
+					return types.MaybeNoSuchOverloadErr(v)
+				}
+
+				h := ts.UTC().Hour()
+				return types.Bool(h >= 9 && h < 17)
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	return store
+}
+
+func TestExtensions(t *testing.T) {
+	store := newVanguard(t)
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}
+
+	at := func(hour int) func() time.Time {
+		return func() time.Time {
+			return time.Date(2021, time.March, 1, hour, 0, 0, 0, time.UTC)
+		}
+	}
+
+	costCenter := func(cc string) vanguard.VariablesFunc {
+		return func(context.Context) (map[string]interface{}, error) {
+			return map[string]interface{}{"cost_center": cc}, nil
+		}
+	}
+
+	testcases := []struct {
+		Name          string
+		Clock         func() time.Time
+		VariablesFunc vanguard.VariablesFunc
+		Code          codes.Code
+	}{
+		{Name: "Allowed", Clock: at(10), VariablesFunc: costCenter("eng"), Code: codes.OK},
+		{Name: "OutsideHours", Clock: at(20), VariablesFunc: costCenter("eng"), Code: codes.PermissionDenied},
+		{Name: "OtherCostCenter", Clock: at(10), VariablesFunc: costCenter("sales"), Code: codes.PermissionDenied},
+		{Name: "Error", Clock: at(10), VariablesFunc: func(context.Context) (map[string]interface{}, error) {
+			return nil, errors.New("unavailable")
+		}, Code: codes.Unknown},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			in := vanguard.Interceptor(store, pf, &vanguard.InterceptorOptions{
+				Clock:         tc.Clock,
+				VariablesFunc: tc.VariablesFunc,
+				ErrorLogger:   func(...interface{}) {},
+			})
+			_, err := in(context.Background(), &extpb.GetProjectRequest{Name: "projects/1", CostCenter: "eng"}, &grpc.UnaryServerInfo{FullMethod: GetProject}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+		})
+	}
+}
+
+func TestLevelSets(t *testing.T) {
+	store := newVanguard(t)
+
+	for _, tc := range []struct {
+		Level int64
+		Code  codes.Code
+	}{
+		{Level: vanguard.LevelOwner, Code: codes.OK},
+		{Level: vanguard.LevelManager, Code: codes.PermissionDenied},
+		{Level: vanguard.LevelEditor, Code: codes.OK},
+	} {
+		in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return []*pb.Permission{{Level: tc.Level, Resources: []string{"projects/1"}}}, nil
+		}, nil)
+
+		_, err := in(context.Background(), &extpb.GetProjectRequest{Name: "projects/1"}, &grpc.UnaryServerInfo{FullMethod: PublishProject}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		if status.Code(err) != tc.Code {
+			t.Fatalf("expected code %v for level %d, got: %v", tc.Code, tc.Level, err)
+		}
+	}
+}
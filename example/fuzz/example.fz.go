@@ -10,6 +10,7 @@ import (
 
 func FuzzExample(msg *pb.Example, c fuzz.Continue) {
     c.Fuzz(&msg.Name)
+    c.Fuzz(&msg.Visibility)
 }
 
 func FuzzListExamplesRequest(msg *pb.ListExamplesRequest, c fuzz.Continue) {
@@ -27,6 +28,12 @@ func FuzzGetExampleRequest(msg *pb.GetExampleRequest, c fuzz.Continue) {
     c.Fuzz(&msg.Name)
 }
 
+func FuzzGetExampleRevisionRequest(msg *pb.GetExampleRevisionRequest, c fuzz.Continue) {
+    c.Fuzz(&msg.Parent)
+    c.Fuzz(&msg.Example)
+    c.Fuzz(&msg.Revision)
+}
+
 func FuzzCreateExampleRequest(msg *pb.CreateExampleRequest, c fuzz.Continue) {
     c.Fuzz(&msg.Parent)
     c.Fuzz(&msg.ExampleId)
@@ -5,6 +5,7 @@ var ff = [...]interface{}{
 	FuzzListExamplesRequest,
 	FuzzListExamplesResponse,
 	FuzzGetExampleRequest,
+	FuzzGetExampleRevisionRequest,
 	FuzzCreateExampleRequest,
 	FuzzUpdateExampleRequest,
 	FuzzDeleteExampleRequest,
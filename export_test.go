@@ -1,3 +1,11 @@
 package vanguard
 
 var VarPool = &varPool
+
+var NewMacros = newMacros
+
+var NewLRUCache = newLRUCache
+
+func (c *lruCache) Get(key interface{}) (interface{}, bool) { return c.get(key) }
+func (c *lruCache) Add(key, value interface{})              { c.add(key, value) }
+func (c *lruCache) Len() int                                { return c.len() }
@@ -0,0 +1,455 @@
+package vanguard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// HTTPMiddleware is a net/http middleware that asserts that a caller has permission to access the endpoints
+// exposed over http using the google.api.http annotations, for example using grpc-gateway.
+//
+// The incoming request is matched against the http rules of all the methods, the rules with a verb and the
+// more specific templates are matched first regardless of the order they are declared in. The path parameters,
+// query parameters and body are decoded into the method's request message, which is then used to evaluate
+// the same assert as the Interceptor. Requests that do not match any rule are forwarded as is.
+//
+// pf is called with the context of the http request, which does not carry grpc metadata.
+// The request headers are exposed to the asserts as `md` instead.
+func HTTPMiddleware(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) (func(http.Handler) http.Handler, error) {
+	opt = opt.withDefaults()
+	if opt.Skip {
+		return func(next http.Handler) http.Handler {
+			return next
+		}, nil
+	}
+
+	routes, err := httpRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, rt := range routes {
+				if rt.verb != r.Method {
+					continue
+				}
+
+				values, ok := rt.tmpl.match(r.URL.EscapedPath())
+				if !ok {
+					continue
+				}
+
+				assert, err := opt.lookup(r.Context(), store, rt.method)
+				if err != nil {
+					writeHTTPError(w, err)
+					return
+				} else if assert == nil {
+					break
+				}
+
+				req, err := rt.decode(r, values)
+				if err != nil {
+					if err := opt.enforce(r.Context(), rt.method, status.Error(codes.InvalidArgument, err.Error())); err != nil {
+						writeHTTPError(w, err)
+						return
+					}
+					break
+				}
+
+				ctx := WithMetadata(r.Context(), httpMetadata(r.Header))
+				ctx = peer.NewContext(ctx, httpPeer(r))
+				d, err := authorize(ctx, rt.method, assert, req, pf, opt)
+				if err := opt.enforce(r.Context(), rt.method, err); err != nil {
+					writeHTTPError(w, err)
+					return
+				}
+
+				r = r.WithContext(withDecision(r.Context(), d))
+
+				break
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// httpMetadata converts the http headers to metadata, as done by grpc-gateway the keys are lower cased
+func httpMetadata(h http.Header) metadata.MD {
+	md := make(metadata.MD, len(h))
+	for k, v := range h {
+		md[strings.ToLower(k)] = v
+	}
+
+	return md
+}
+
+// httpPeer converts the remote address and the tls state of the request to a grpc peer
+func httpPeer(r *http.Request) *peer.Peer {
+	p := &peer.Peer{Addr: stringAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+
+	return p
+}
+
+type httpRoute struct {
+	verb   string
+	tmpl   *httpTemplate
+	body   string
+	input  protoreflect.MessageType
+	method string
+}
+
+// httpRoutes reads the google.api.http rules of all the methods
+func httpRoutes() ([]*httpRoute, error) {
+	var (
+		routes []*httpRoute
+		me     = MultiError{}
+	)
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			s := services.Get(i)
+			methods := s.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				m := methods.Get(j)
+				if m.IsStreamingClient() {
+					continue
+				}
+
+				rule, _ := proto.GetExtension(m.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if rule == nil {
+					continue
+				}
+
+				input, err := protoregistry.GlobalTypes.FindMessageByName(m.Input().FullName())
+				if err != nil {
+					me = append(me, fmt.Errorf("vanguard: unable to find proto type: %s, err: %w", string(m.Input().FullName()), err))
+					continue
+				}
+
+				for _, r := range append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...) {
+					rt, err := newHTTPRoute(r, input, "/"+string(s.FullName())+"/"+string(m.Name()))
+					if err != nil {
+						me = append(me, fmt.Errorf("vanguard: invalid http rule on method: %s, err: %w", m.FullName(), err))
+						continue
+					}
+					routes = append(routes, rt)
+				}
+			}
+		}
+
+		return true
+	})
+
+	if len(me) > 0 {
+		return nil, me
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].tmpl.moreSpecific(routes[j].tmpl)
+	})
+
+	return routes, nil
+}
+
+func newHTTPRoute(rule *annotations.HttpRule, input protoreflect.MessageType, method string) (*httpRoute, error) {
+	rt := &httpRoute{
+		body:   rule.Body,
+		input:  input,
+		method: method,
+	}
+
+	var tmpl string
+	switch p := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		rt.verb, tmpl = http.MethodGet, p.Get
+	case *annotations.HttpRule_Put:
+		rt.verb, tmpl = http.MethodPut, p.Put
+	case *annotations.HttpRule_Post:
+		rt.verb, tmpl = http.MethodPost, p.Post
+	case *annotations.HttpRule_Delete:
+		rt.verb, tmpl = http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		rt.verb, tmpl = http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		rt.verb, tmpl = p.Custom.GetKind(), p.Custom.GetPath()
+	default:
+		return nil, fmt.Errorf("unknown http pattern: %T", p)
+	}
+
+	var err error
+	rt.tmpl, err = parseHTTPTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	md := input.Descriptor()
+	for _, v := range rt.tmpl.vars {
+		if _, err := httpFieldPath(md, v.field); err != nil {
+			return nil, err
+		}
+	}
+
+	if rt.body != "" && rt.body != "*" {
+		fd := md.Fields().ByName(protoreflect.Name(rt.body))
+		if fd == nil {
+			return nil, fmt.Errorf("unknown body field: %s", rt.body)
+		} else if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return nil, fmt.Errorf("unsupported body field: %s, only singular message fields are supported", rt.body)
+		}
+	}
+
+	return rt, nil
+}
+
+// decode builds the request message from the body, the query and the path parameters in that order.
+// As done by grpc-gateway, the query parameters that fall under the body field or a path parameter are ignored,
+// so that the message is the same as the one received by the backend.
+func (rt *httpRoute) decode(r *http.Request, values map[*httpVar]string) (proto.Message, error) {
+	msg := rt.input.New()
+	uo := protojson.UnmarshalOptions{DiscardUnknown: true}
+
+	if rt.body != "" && r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+		if len(b) > 0 {
+			target := msg
+			if rt.body != "*" {
+				target = msg.Mutable(msg.Descriptor().Fields().ByName(protoreflect.Name(rt.body))).Message()
+			}
+
+			if err := uo.Unmarshal(b, target.Interface()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if rt.body != "*" {
+		for key, vv := range r.URL.Query() {
+			path := strings.Split(key, ".")
+			if rt.filtered(path) {
+				continue
+			}
+
+			fds, err := httpFieldPath(msg.Descriptor(), path)
+			if err != nil {
+				// Unknown query parameters are ignored
+				continue
+			}
+
+			if err := setHTTPField(msg, fds, vv); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for v, value := range values {
+		fds, _ := httpFieldPath(msg.Descriptor(), v.field)
+		if err := setHTTPField(msg, fds, []string{value}); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg.Interface(), nil
+}
+
+// filtered reports whether the query parameter path is the body field or a path parameter, or sits under one of them
+func (rt *httpRoute) filtered(path []string) bool {
+	if rt.body != "" && path[0] == rt.body {
+		return true
+	}
+
+	for _, v := range rt.tmpl.vars {
+		if hasFieldPrefix(path, v.field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasFieldPrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// httpFieldPath resolves a field path using either the proto or the json names of the fields
+func httpFieldPath(md protoreflect.MessageDescriptor, path []string) ([]protoreflect.FieldDescriptor, error) {
+	fds := make([]protoreflect.FieldDescriptor, 0, len(path))
+	for i, name := range path {
+		if md == nil {
+			return nil, fmt.Errorf("field is not a message: %s", strings.Join(path[:i], "."))
+		}
+
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(name)
+		}
+		if fd == nil || fd.IsMap() || (fd.IsList() && i != len(path)-1) {
+			return nil, fmt.Errorf("unknown or unsupported field: %s", strings.Join(path[:i+1], "."))
+		}
+
+		fds = append(fds, fd)
+		md = fd.Message()
+	}
+
+	return fds, nil
+}
+
+func setHTTPField(msg protoreflect.Message, fds []protoreflect.FieldDescriptor, values []string) error {
+	for _, fd := range fds[:len(fds)-1] {
+		msg = msg.Mutable(fd).Message()
+	}
+
+	fd := fds[len(fds)-1]
+	if !fd.IsList() && len(values) > 1 {
+		return fmt.Errorf("repeated values for a singular field: %s", fd.FullName())
+	}
+
+	for _, s := range values {
+		var (
+			v   protoreflect.Value
+			err error
+		)
+		if fd.Message() != nil {
+			var m protoreflect.Message
+			if fd.IsList() {
+				m = msg.Mutable(fd).List().NewElement().Message()
+			} else {
+				m = msg.NewField(fd).Message()
+			}
+			err = protojson.Unmarshal([]byte(strconv.Quote(s)), m.Interface())
+			v = protoreflect.ValueOfMessage(m)
+		} else {
+			v, err = parseHTTPValue(fd, s)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for field: %s, err: %w", fd.FullName(), err)
+		}
+
+		if fd.IsList() {
+			msg.Mutable(fd).List().Append(v)
+		} else {
+			msg.Set(fd, v)
+		}
+	}
+
+	return nil
+}
+
+func parseHTTPValue(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		v, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			v, err = base64.URLEncoding.DecodeString(s)
+		}
+		return protoreflect.ValueOfBytes(v), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported kind: %v", fd.Kind())
+	}
+}
+
+// writeHTTPError writes err as a json encoded google.rpc.Status
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	b, _ := protojson.Marshal(st.Proto())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	w.Write(b)
+}
+
+// httpStatusFromCode follows the mapping documented in google/rpc/code.proto
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,79 @@
+package vanguard_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/srikrsna/vanguard"
+	pb "github.com/srikrsna/vanguard/vanguard"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: Editor, Resources: []string{"parents/12422/**"}},
+			{Level: Owner, Resources: []string{"parents/12424/examples/1"}},
+		}, nil
+	}
+
+	mw, err := vanguard.HTTPMiddleware(store, pf, nil)
+	if err != nil {
+		t.Fatalf("unable to create middleware: %v", err)
+	}
+
+	testcases := []struct {
+		Name   string
+		Method string
+		Path   string
+		Body   string
+		Status int
+	}{
+		{Name: "List", Method: http.MethodGet, Path: "/v1/parents/12422/examples?pageSize=10", Status: http.StatusOK},
+		{Name: "ListDenied", Method: http.MethodGet, Path: "/v1/parents/12423/examples", Status: http.StatusForbidden},
+		{Name: "Get", Method: http.MethodGet, Path: "/v1/parents/12422/examples/1", Status: http.StatusOK},
+		{Name: "GetVerbDenied", Method: http.MethodGet, Path: "/v1/parents/12422/examples/1:share", Status: http.StatusForbidden},
+		{Name: "GetVerb", Method: http.MethodGet, Path: "/v1/parents/12424/examples/1:share", Status: http.StatusOK},
+		{Name: "GetDenied", Method: http.MethodGet, Path: "/v1/parents/12423/examples/1", Status: http.StatusForbidden},
+		{Name: "Create", Method: http.MethodPost, Path: "/v1/parents/12422/examples?exampleId=1", Body: `{"name": "ignored"}`, Status: http.StatusOK},
+		{Name: "CreateDenied", Method: http.MethodPost, Path: "/v1/parents/12423/examples", Body: `{}`, Status: http.StatusForbidden},
+		{Name: "Update", Method: http.MethodPatch, Path: "/v1/parents/12422/examples/1", Body: `{"name": "parents/12423/examples/1"}`, Status: http.StatusOK},
+		{Name: "UpdateQueryUnderBody", Method: http.MethodPatch, Path: "/v1/parents/12422/examples/1?example.visibility=PRIVATE", Body: `{"visibility": "PUBLIC"}`, Status: http.StatusForbidden},
+		{Name: "UpdateBodyTrailingField", Method: http.MethodPatch, Path: "/v1/parents/12422/examples/1", Body: `{"visibility": "PUBLIC"}, "x": {}`, Status: http.StatusBadRequest},
+		{Name: "UpdateDenied", Method: http.MethodPatch, Path: "/v1/parents/12423/examples/1", Body: `{}`, Status: http.StatusForbidden},
+		{Name: "Delete", Method: http.MethodDelete, Path: "/v1/parents/12424/examples/1", Status: http.StatusOK},
+		{Name: "DeleteDenied", Method: http.MethodDelete, Path: "/v1/parents/12422/examples/1", Status: http.StatusForbidden},
+		{Name: "BadQuery", Method: http.MethodGet, Path: "/v1/parents/12422/examples?pageSize=ten", Status: http.StatusBadRequest},
+		{Name: "Unmatched", Method: http.MethodGet, Path: "/healthz", Status: http.StatusOK},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			body := ""
+			h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+			}))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.Method, tc.Path, strings.NewReader(tc.Body)))
+
+			if rec.Code != tc.Status {
+				t.Fatalf("status mismatch, exp: %d, act: %d, body: %s", tc.Status, rec.Code, rec.Body.String())
+			}
+
+			if tc.Status == http.StatusOK && body != tc.Body {
+				t.Fatalf("body was not forwarded, exp: %q, act: %q", tc.Body, body)
+			}
+		})
+	}
+}
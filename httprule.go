@@ -0,0 +1,184 @@
+package vanguard
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// httpTemplate is a parsed google.api.http path template.
+//
+// The grammar is documented in google/api/http.proto as follows,
+//
+//	Template = "/" Segments [ Verb ] ;
+//	Segments = Segment { "/" Segment } ;
+//	Segment  = "*" | "**" | LITERAL | Variable ;
+//	Variable = "{" FieldPath [ "=" Segments ] "}" ;
+//	FieldPath = IDENT { "." IDENT } ;
+//	Verb     = ":" LITERAL ;
+type httpTemplate struct {
+	// segments are either literals, "*" or "**"
+	segments []string
+	vars     []httpVar
+	verb     string
+}
+
+// httpVar binds the segments [start, end) of a template to a field
+type httpVar struct {
+	field      []string
+	start, end int
+}
+
+func parseHTTPTemplate(tmpl string) (*httpTemplate, error) {
+	if !strings.HasPrefix(tmpl, "/") {
+		return nil, fmt.Errorf("vanguard: http template must start with '/', got: %q", tmpl)
+	}
+
+	t := &httpTemplate{}
+	path := tmpl[1:]
+	if i := strings.LastIndexByte(path, ':'); i >= 0 && !strings.ContainsAny(path[i:], "/}") {
+		path, t.verb = path[:i], path[i+1:]
+	}
+
+	for len(path) > 0 {
+		if path[0] == '{' {
+			end := strings.IndexByte(path, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("vanguard: unterminated variable in http template: %q", tmpl)
+			}
+
+			field, pattern := path[1:end], "*"
+			if i := strings.IndexByte(field, '='); i >= 0 {
+				field, pattern = field[:i], field[i+1:]
+			}
+			path = path[end+1:]
+
+			if field == "" {
+				return nil, fmt.Errorf("vanguard: empty variable in http template: %q", tmpl)
+			}
+
+			start := len(t.segments)
+			for _, seg := range strings.Split(pattern, "/") {
+				if seg == "" || strings.ContainsAny(seg, "{}=") {
+					return nil, fmt.Errorf("vanguard: invalid segment %q in http template: %q", seg, tmpl)
+				}
+				t.segments = append(t.segments, seg)
+			}
+			t.vars = append(t.vars, httpVar{field: strings.Split(field, "."), start: start, end: len(t.segments)})
+		} else {
+			end := strings.IndexByte(path, '/')
+			if end < 0 {
+				end = len(path)
+			}
+
+			seg := path[:end]
+			if seg == "" || strings.ContainsAny(seg, "{}=") {
+				return nil, fmt.Errorf("vanguard: invalid segment %q in http template: %q", seg, tmpl)
+			}
+			t.segments = append(t.segments, seg)
+			path = path[end:]
+		}
+
+		if len(path) > 0 {
+			if path[0] != '/' || len(path) == 1 {
+				return nil, fmt.Errorf("vanguard: invalid http template: %q", tmpl)
+			}
+			path = path[1:]
+		}
+	}
+
+	for i, seg := range t.segments {
+		if seg == "**" && i != len(t.segments)-1 {
+			return nil, fmt.Errorf("vanguard: '**' is only allowed as the last segment in http template: %q", tmpl)
+		}
+	}
+
+	return t, nil
+}
+
+// match matches an escaped url path against the template and returns the
+// values of the variables if it matches. A template without a verb does not match a path that ends in one,
+// Eg: /v1/{name=examples/*} does not match /v1/examples/1:export
+func (t *httpTemplate) match(path string) (map[*httpVar]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	path = path[1:]
+
+	if t.verb != "" {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+		path = strings.TrimSuffix(path, ":"+t.verb)
+	}
+
+	comps := strings.Split(path, "/")
+	if t.verb == "" && strings.Contains(comps[len(comps)-1], ":") {
+		return nil, false
+	}
+
+	deep := t.deep()
+	if (!deep && len(comps) != len(t.segments)) || (deep && len(comps) < len(t.segments)-1) {
+		return nil, false
+	}
+
+	for i, seg := range t.segments {
+		switch seg {
+		case "**":
+		case "*":
+			if comps[i] == "" {
+				return nil, false
+			}
+		default:
+			if comps[i] != seg {
+				return nil, false
+			}
+		}
+	}
+
+	values := make(map[*httpVar]string, len(t.vars))
+	for i := range t.vars {
+		v := &t.vars[i]
+		end := v.end
+		if end == len(t.segments) && deep {
+			end = len(comps)
+		}
+
+		value, err := url.PathUnescape(strings.Join(comps[v.start:end], "/"))
+		if err != nil {
+			return nil, false
+		}
+		values[v] = value
+	}
+
+	return values, true
+}
+
+// moreSpecific reports whether t should be matched before o. Templates with a verb come first,
+// then the ones with more literal segments and lastly the ones that end in "**".
+func (t *httpTemplate) moreSpecific(o *httpTemplate) bool {
+	if (t.verb != "") != (o.verb != "") {
+		return t.verb != ""
+	}
+
+	if tl, ol := t.literals(), o.literals(); tl != ol {
+		return tl > ol
+	}
+
+	return !t.deep() && o.deep()
+}
+
+func (t *httpTemplate) literals() int {
+	n := 0
+	for _, seg := range t.segments {
+		if seg != "*" && seg != "**" {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (t *httpTemplate) deep() bool {
+	return len(t.segments) > 0 && t.segments[len(t.segments)-1] == "**"
+}
@@ -2,83 +2,343 @@ package vanguard
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
 	"github.com/google/cel-go/interpreter"
+	"github.com/srikrsna/glob"
 	pb "github.com/srikrsna/vanguard/vanguard"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 type ErrorLogger func(v ...interface{})
 
-// PermissionsFunc is used to retreive the permissions of the current user.
-// The context passed is an incoming grpc context.
+// ShadowReporter is called with the decision of every call that is evaluated in shadow mode.
+// A nil err means that the call would have been allowed.
+type ShadowReporter func(ctx context.Context, method string, err error)
+
+// PermissionsFunc retrieves the permissions of the current user from the context of the call.
+// Each interceptor documents which context it passes and how often it is called.
 //
-// If it returns an error, it will be returned to the user.
+// An error denies the call, the DenialFunc builds the error that is returned to the user from it.
 type PermissionsFunc func(context.Context) ([]*Permission, error)
 
+// SubjectFunc identifies the caller, the returned Subject is exposed to the asserts as `s`.
+// It is called right after the PermissionsFunc with the same context, a nil Subject is treated as an empty one.
+//
+// An error denies the call the same as an error from the PermissionsFunc.
+type SubjectFunc func(context.Context) (*Subject, error)
+
+// VariablesFunc returns the values of the variables declared using `WithDeclarations` for a call, keyed by their names.
+// An assert that uses a variable missing from the map fails to evaluate.
+//
+// An error denies the call the same as an error from the PermissionsFunc.
+type VariablesFunc func(context.Context) (map[string]interface{}, error)
+
 type InterceptorOptions struct {
 	Skip        bool
 	ErrorLogger ErrorLogger
+
+	// DenyByDefault rejects the calls to methods that do not have an assert,
+	// unless they are marked with the (vanguard.public) option or match one of the PublicMethods.
+	DenyByDefault bool
+	// PublicMethods is a list of glob patterns of fully qualified method names
+	// that are allowed without an assert when DenyByDefault is set. Eg: /grpc.health.v1.Health/*
+	//
+	// Look at `DefaultPublicMethods` for the grpc health and reflection services
+	PublicMethods []string
+
+	// Shadow evaluates the asserts of all the methods without enforcing them.
+	// The decision is reported to the ShadowReporter and the handler is always called.
+	Shadow bool
+	// ShadowMethods is a list of glob patterns of fully qualified method names
+	// that are evaluated in shadow mode. Eg: /example.ExampleService/* or /example.ExampleService/GetExample
+	ShadowMethods []string
+	// ShadowReporter defaults to logging the calls that would have been denied using the ErrorLogger
+	ShadowReporter ShadowReporter
+
+	// DenialFunc builds the error that is returned when a call is denied, defaults to DefaultDenial
+	DenialFunc DenialFunc
+
+	// SubjectFunc is optional, `s` is an empty Subject if it is nil
+	SubjectFunc SubjectFunc
+
+	// VariablesFunc is only needed if variables are declared using `WithDeclarations`.
+	// The variables of vanguard, like `u` and `r`, can not be overridden.
+	VariablesFunc VariablesFunc
+
+	// Clock is used to get the time at which the asserts are evaluated, it is available to the asserts as `now`.
+	// It is also used to check the validity of time bound permissions. Defaults to time.Now
+	Clock func() time.Time
+
+	// MetadataKeys restricts the keys of the incoming metadata that are exposed to the asserts as `md`.
+	// All the keys are exposed if it is nil.
+	MetadataKeys []string
+}
+
+// DefaultPublicMethods are the grpc health check and reflection services.
+// They are typically added to InterceptorOptions.PublicMethods when using DenyByDefault.
+func DefaultPublicMethods() []string {
+	return []string{
+		"/grpc.health.v1.Health/*",
+		"/grpc.reflection.v1alpha.ServerReflection/*",
+		"/grpc.reflection.v1.ServerReflection/*",
+	}
 }
 
 // Interceptor is grpc UnaryServerInterceptor that asserts that a caller has permission to access the endpoints.
-// PermissionsFunc is used  to retreive the permissions of the current user
+// pf is called with the incoming context of every call that has an assert.
 func Interceptor(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) grpc.UnaryServerInterceptor {
+	opt = opt.withDefaults()
 	if opt.Skip {
 		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 			return handler(ctx, req)
 		}
 	}
 
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		d, err := opt.check(ctx, store, info.FullMethod, req, pf)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(withDecision(ctx, d), req)
+	}
+}
+
+func (opt *InterceptorOptions) withDefaults() *InterceptorOptions {
+	if opt == nil {
+		opt = &InterceptorOptions{}
+	}
+
 	if opt.ErrorLogger == nil {
 		opt.ErrorLogger = log.Println
 	}
 
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		assert, ok := store[info.FullMethod]
-		if !ok {
-			return handler(ctx, req)
+	if opt.Clock == nil {
+		opt.Clock = time.Now
+	}
+
+	if opt.DenialFunc == nil {
+		opt.DenialFunc = DefaultDenial
+	}
+
+	if opt.ShadowReporter == nil {
+		logger := opt.ErrorLogger
+		opt.ShadowReporter = func(_ context.Context, method string, err error) {
+			if err != nil {
+				logger("vanguard: shadow mode: call to", method, "would have been denied:", err)
+			}
 		}
+	}
 
-		perms, err := pf(ctx)
-		if err != nil {
-			return nil, err
+	return opt
+}
+
+// enforce returns err as is, unless method is evaluated in shadow mode.
+// In which case the decision is reported and nil is returned.
+func (opt *InterceptorOptions) enforce(ctx context.Context, method string, err error) error {
+	if !opt.shadow(method) {
+		return err
+	}
+
+	opt.ShadowReporter(ctx, method, err)
+	return nil
+}
+
+func (opt *InterceptorOptions) shadow(method string) bool {
+	if opt.Shadow {
+		return true
+	}
+
+	for _, p := range opt.ShadowMethods {
+		if ok, _ := glob.Match(p, method); ok {
+			return true
 		}
+	}
+
+	return false
+}
 
-		vars := varPool.Get()
-		defer varPool.Put(vars)
+// check evaluates the assert of method against req and enforces the outcome.
+// The decision is nil for the calls that are allowed without an assert.
+func (opt *InterceptorOptions) check(ctx context.Context, store Vanguard, method string, req interface{}, pf PermissionsFunc) (*Decision, error) {
+	assert, err := opt.lookup(ctx, store, method)
+	if err != nil || assert == nil {
+		return nil, err
+	}
 
-		vars.R = req
-		vars.U = perms
+	d, err := authorize(ctx, method, assert, req, pf, opt)
+	return d, opt.enforce(ctx, method, err)
+}
 
-		v, _, err := assert.Eval(vars)
-		if err != nil {
-			opt.ErrorLogger("vanguard: unable to evaluate access assertions, most likely a bug in vanguard, please open an issue: %v", err)
-			return nil, status.Error(codes.Unknown, "Unknown error")
+// lookup returns the assert of method, a nil assert means that the call is allowed without any checks.
+// The returned error is already enforced.
+func (opt *InterceptorOptions) lookup(ctx context.Context, store Vanguard, method string) (cel.Program, error) {
+	assert, ok := store[method]
+	if ok && assert != Public {
+		return assert, nil
+	}
+
+	if ok || !opt.DenyByDefault {
+		return nil, nil
+	}
+
+	for _, p := range opt.PublicMethods {
+		if ok, _ := glob.Match(p, method); ok {
+			return nil, nil
+		}
+	}
+
+	return nil, opt.enforce(ctx, method, opt.DenialFunc(ctx, &Denial{Method: method}))
+}
+
+// authorize evaluates assert against req and the permissions returned by pf.
+// It returns a grpc status error if the caller is not allowed.
+func authorize(ctx context.Context, method string, assert cel.Program, req interface{}, pf PermissionsFunc, opt *InterceptorOptions) (*Decision, error) {
+	vars, err := opt.activation(ctx, method, pf)
+	if err != nil {
+		return nil, err
+	}
+	defer varPool.Put(vars)
+
+	vars.R = req
+
+	return evaluate(ctx, method, assert, vars, opt)
+}
+
+// retrieveError builds the error returned when the PermissionsFunc or the SubjectFunc fail,
+// errors that are not grpc status errors are logged as they are most likely internal errors.
+func (opt *InterceptorOptions) retrieveError(ctx context.Context, method, what string, err error) error {
+	if _, ok := status.FromError(err); !ok {
+		opt.ErrorLogger("vanguard: unable to retrieve "+what+":", err)
+	}
+
+	return opt.DenialFunc(ctx, &Denial{Method: method, Err: err})
+}
+
+// activation returns an activation from the pool with everything but the request set.
+// The returned error is built by the DenialFunc and is not enforced.
+func (opt *InterceptorOptions) activation(ctx context.Context, method string, pf PermissionsFunc) (*activation, error) {
+	perms, err := pf(ctx)
+	if err != nil {
+		return nil, opt.retrieveError(ctx, method, "permissions", err)
+	}
+
+	sub := &Subject{}
+	if opt.SubjectFunc != nil {
+		if sub, err = opt.SubjectFunc(ctx); err != nil {
+			return nil, opt.retrieveError(ctx, method, "subject", err)
+		} else if sub == nil {
+			sub = &Subject{}
 		}
+	}
 
-		allow, ok := v.Value().(bool)
-		if !ok {
-			opt.ErrorLogger("vanguard: unable to evaluate access assertions to bool, most likely a bug in vanguard, please open an issue: type: %[0]T, value: %[0]v", v.Value())
-			return nil, status.Error(codes.Unknown, "Unknown error")
+	var extra map[string]interface{}
+	if opt.VariablesFunc != nil {
+		if extra, err = opt.VariablesFunc(ctx); err != nil {
+			return nil, opt.retrieveError(ctx, method, "variables", err)
 		}
+	}
+
+	vars := varPool.Get()
+	vars.Method = method
+	vars.U = perms
+	vars.Vars = extra
+	vars.S = sub
+	vars.MD = opt.metadata(ctx)
+	vars.Peer, _ = peer.FromContext(ctx)
+	return vars, nil
+}
+
+// metadata returns the metadata that is exposed to the asserts
+func (opt *InterceptorOptions) metadata(ctx context.Context) metadata.MD {
+	md, ok := ctx.Value(metadataKey{}).(metadata.MD)
+	if !ok {
+		md, _ = metadata.FromIncomingContext(ctx)
+	}
 
-		if !allow {
-			return nil, status.Error(codes.PermissionDenied, codes.PermissionDenied.String())
+	if md == nil {
+		md = metadata.MD{}
+	}
+
+	if opt.MetadataKeys == nil {
+		return md
+	}
+
+	filtered := make(metadata.MD, len(opt.MetadataKeys))
+	for _, k := range opt.MetadataKeys {
+		if v := md.Get(k); v != nil {
+			filtered[strings.ToLower(k)] = v
 		}
+	}
+
+	return filtered
+}
+
+type metadataKey struct{}
 
-		return handler(ctx, req)
+// WithMetadata sets the metadata that is exposed to the asserts as `md`, instead of the incoming grpc metadata.
+// It is meant for integrating vanguard with other rpc frameworks, the keys must be lower case.
+func WithMetadata(ctx context.Context, md metadata.MD) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// evaluate evaluates assert using vars, the decision is returned even if the call is denied
+func evaluate(ctx context.Context, method string, assert cel.Program, vars *activation, opt *InterceptorOptions) (*Decision, error) {
+	vars.state = evalState{now: opt.Clock()}
+	start := time.Now()
+	v, _, err := assert.Eval(vars)
+	if err != nil && vars.state.noLevel {
+		// levelOn fails if the user does not have any permission on the resource, which is a denial
+		v = types.False
+	} else if err != nil {
+		opt.ErrorLogger("vanguard: unable to evaluate access assertions, most likely a bug in vanguard, please open an issue: %v", err)
+		return nil, opt.DenialFunc(ctx, &Denial{Method: method, Err: err})
+	}
+
+	allow, ok := v.Value().(bool)
+	if !ok {
+		opt.ErrorLogger("vanguard: unable to evaluate access assertions to bool, most likely a bug in vanguard, please open an issue: type: %[0]T, value: %[0]v", v.Value())
+		return nil, opt.DenialFunc(ctx, &Denial{Method: method, Err: fmt.Errorf("vanguard: assert evaluated to %T instead of bool", v.Value())})
+	}
+
+	for _, err := range vars.state.errs {
+		opt.ErrorLogger("vanguard: unable to evaluate permission for method: "+method+", treating it as a denial:", err)
+	}
+
+	d := &Decision{
+		Method:      method,
+		Allowed:     allow,
+		Permissions: vars.U,
+		Subject:     vars.S,
+		Matched:     vars.state.matched,
+		Time:        start,
+		Duration:    time.Since(start),
 	}
+	if !allow {
+		return d, opt.DenialFunc(ctx, vars.state.denial(method))
+	}
+
+	return d, nil
 }
 
 type varPoolType sync.Pool
 
 func (vp *varPoolType) Get() *activation {
-	return ((*sync.Pool)(vp)).Get().(*activation)
+	a := ((*sync.Pool)(vp)).Get().(*activation)
+	a.state = evalState{}
+	return a
 }
 
 func (vp *varPoolType) Put(a *activation) {
@@ -94,8 +354,20 @@ var varPool = varPoolType{
 var _ interpreter.Activation = (*activation)(nil)
 
 type activation struct {
-	R interface{}
-	U []*pb.Permission
+	// Method is the full method name of the call, it is matched against the methods of the permissions
+	Method string
+
+	R  interface{}
+	U  []*pb.Permission
+	S  *pb.Subject
+	MD metadata.MD
+
+	// Peer is converted to a cel value only if it is used
+	Peer *peer.Peer
+	// Vars are the values of the user declared variables
+	Vars map[string]interface{}
+
+	state evalState
 }
 
 func (a *activation) ResolveName(name string) (interface{}, bool) {
@@ -103,10 +375,115 @@ func (a *activation) ResolveName(name string) (interface{}, bool) {
 	case "r":
 		return a.R, true
 	case "u":
-		return a.U, true
+		return &permissionList{
+			Lister: types.NewDynamicList(permissionAdapter, a.U),
+			state:  &a.state,
+			vars:   a,
+		}, true
+	case "s":
+		return a.S, true
+	case "now":
+		return types.Timestamp{Time: a.state.time()}, true
+	case "md":
+		return a.MD, true
+	case "peer":
+		return peerValue(a.Peer), true
 	default:
-		return nil, false
+		v, ok := a.Vars[name]
+		return v, ok
 	}
 }
 
 func (a *activation) Parent() interpreter.Activation { return nil }
+
+// permissionAdapter is used to adapt the permissions of the user to cel values
+var permissionAdapter, _ = types.NewRegistry((*pb.Permission)(nil))
+
+// permissionList is the value of `u` during an evaluation. It behaves as a list of permissions,
+// while carrying the state of the evaluation that is updated by functions like hasAny and hasAll.
+type permissionList struct {
+	traits.Lister
+
+	state *evalState
+	// vars are used to evaluate the methods and conditions of the permissions
+	vars *activation
+}
+
+// evalState is the state of a single evaluation of an assert
+type evalState struct {
+	// now is the time of the evaluation, it is set lazily if the clock is not available
+	now time.Time
+
+	// matched are the permissions that satisfied the hasAny and hasAll checks
+	matched []*pb.Permission
+
+	// noLevel is set if levelOn did not find any permission on a resource
+	noLevel bool
+
+	// denied is set if a hasAny, hasAll or levelOn check failed, the fields below describe the last one that did
+	denied    bool
+	level     int64
+	levelName string
+	resources []string
+
+	// errs are the errors of the conditions and method patterns of the permissions that could not be evaluated
+	errs []error
+}
+
+// stateOf returns the evaluation state carried by u, it is nil if u was not resolved from an activation
+func stateOf(u ref.Val) *evalState {
+	if pl, ok := u.(*permissionList); ok {
+		return pl.state
+	}
+
+	return nil
+}
+
+// timeOf returns the time of the evaluation carried by u
+func timeOf(u ref.Val) time.Time {
+	if state := stateOf(u); state != nil {
+		return state.time()
+	}
+
+	return time.Now()
+}
+
+func (s *evalState) time() time.Time {
+	if s.now.IsZero() {
+		s.now = time.Now()
+	}
+
+	return s.now
+}
+
+func (s *evalState) match(perm *pb.Permission) {
+	for _, m := range s.matched {
+		if m == perm {
+			return
+		}
+	}
+
+	s.matched = append(s.matched, perm)
+}
+
+func (s *evalState) deny(level int64, levelName string, resources []string) {
+	s.denied = true
+	s.level = level
+	s.levelName = levelName
+	s.resources = resources
+}
+
+func (s *evalState) fail(err error) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *evalState) denial(method string) *Denial {
+	d := &Denial{Method: method}
+	if s.denied {
+		d.Level = s.level
+		d.LevelName = s.levelName
+		d.Resources = s.resources
+	}
+
+	return d
+}
@@ -0,0 +1,722 @@
+package vanguard_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ListPublic = Service + "/ListPublicExamples"
+	Search     = Service + "/SearchExamples"
+	Sync       = Service + "/SyncExamples"
+	Claim      = Service + "/ClaimExample"
+	Archive    = Service + "/ArchiveExample"
+	Share      = Service + "/ShareExample"
+)
+
+func TestInterceptorDenyByDefault(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return nil, errors.New("unauthenticated")
+	}
+
+	testcases := []struct {
+		Name          string
+		Method        string
+		DenyByDefault bool
+		Code          codes.Code
+	}{
+		{Name: "Public", Method: ListPublic, DenyByDefault: true, Code: codes.OK},
+		{Name: "Allowlist", Method: "/grpc.health.v1.Health/Check", DenyByDefault: true, Code: codes.OK},
+		{Name: "NoAssert", Method: Service + "/Unknown", DenyByDefault: true, Code: codes.PermissionDenied},
+		{Name: "NoAssertAllowed", Method: Service + "/Unknown", DenyByDefault: false, Code: codes.OK},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			in := vanguard.Interceptor(store, pf, &vanguard.InterceptorOptions{
+				DenyByDefault: tc.DenyByDefault,
+				PublicMethods: vanguard.DefaultPublicMethods(),
+			})
+
+			_, err := in(context.Background(), &expb.ListExamplesRequest{}, &grpc.UnaryServerInfo{FullMethod: tc.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("code mismatch, exp: %v, act: %v", tc.Code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestInterceptorShadow(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: Viewer, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}
+
+	testcases := []struct {
+		Name          string
+		Method        string
+		Request       interface{}
+		Options       vanguard.InterceptorOptions
+		Code          codes.Code
+		Reported      bool
+		ReportedError bool
+	}{
+		{Name: "Global", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/12423/examples/1"}, Options: vanguard.InterceptorOptions{Shadow: true}, Code: codes.OK, Reported: true, ReportedError: true},
+		{Name: "GlobalAllowed", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/12422/examples/1"}, Options: vanguard.InterceptorOptions{Shadow: true}, Code: codes.OK, Reported: true},
+		{Name: "Service", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/12423/examples/1"}, Options: vanguard.InterceptorOptions{ShadowMethods: []string{Service + "/*"}}, Code: codes.OK, Reported: true, ReportedError: true},
+		{Name: "Method", Method: Delete, Request: &expb.DeleteExampleRequest{Name: "/parents/12422/examples/1"}, Options: vanguard.InterceptorOptions{ShadowMethods: []string{Delete}}, Code: codes.OK, Reported: true, ReportedError: true},
+		{Name: "OtherMethod", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/12423/examples/1"}, Options: vanguard.InterceptorOptions{ShadowMethods: []string{Delete}}, Code: codes.PermissionDenied},
+		{Name: "DenyByDefault", Method: Service + "/Unknown", Request: &expb.GetExampleRequest{}, Options: vanguard.InterceptorOptions{Shadow: true, DenyByDefault: true}, Code: codes.OK, Reported: true, ReportedError: true},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var (
+				reported bool
+				rerr     error
+			)
+			opt := tc.Options
+			opt.ShadowReporter = func(_ context.Context, method string, err error) {
+				if method != tc.Method {
+					t.Errorf("method mismatch, exp: %s, act: %s", tc.Method, method)
+				}
+				reported, rerr = true, err
+			}
+
+			called := false
+			in := vanguard.Interceptor(store, pf, &opt)
+			_, err := in(context.Background(), tc.Request, &grpc.UnaryServerInfo{FullMethod: tc.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("code mismatch, exp: %v, act: %v", tc.Code, status.Code(err))
+			}
+
+			if called != (tc.Code == codes.OK) {
+				t.Fatalf("handler call mismatch, called: %v", called)
+			}
+
+			if reported != tc.Reported || (rerr != nil) != tc.ReportedError {
+				t.Fatalf("report mismatch, reported: %v, err: %v", reported, rerr)
+			}
+		})
+	}
+}
+
+func TestInterceptorDenial(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: Update}
+	req := &expb.UpdateExampleRequest{Example: &expb.Example{Name: "/parents/12423/examples/1"}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	t.Run("ErrorInfo", func(t *testing.T) {
+		in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return []*pb.Permission{{Level: Viewer, Resources: []string{"/parents/12423/**"}}}, nil
+		}, nil)
+
+		_, err := in(context.Background(), req, info, handler)
+		st := status.Convert(err)
+		if st.Code() != codes.PermissionDenied {
+			t.Fatalf("expected permission denied, got: %v", err)
+		}
+
+		if len(st.Details()) != 1 {
+			t.Fatalf("expected one detail, got: %v", st.Details())
+		}
+
+		ei, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		if !ok {
+			t.Fatalf("expected error info, got: %T", st.Details()[0])
+		}
+
+		exp := map[string]string{"method": Update, "level": "EDITOR", "resources": "/parents/12423/examples/1"}
+		if !reflect.DeepEqual(ei.Metadata, exp) {
+			t.Fatalf("metadata mismatch, exp: %v, act: %v", exp, ei.Metadata)
+		}
+	})
+
+	t.Run("PermissionsError", func(t *testing.T) {
+		in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return nil, errors.New("db: connection refused")
+		}, &vanguard.InterceptorOptions{ErrorLogger: func(...interface{}) {}})
+
+		_, err := in(context.Background(), req, info, handler)
+		if status.Code(err) != codes.Unknown || strings.Contains(err.Error(), "db") {
+			t.Fatalf("expected an unknown error without details, got: %v", err)
+		}
+
+		in = vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}, nil)
+
+		_, err = in(context.Background(), req, info, handler)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected status errors to be returned as is, got: %v", err)
+		}
+	})
+
+	t.Run("DenialFunc", func(t *testing.T) {
+		in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return nil, nil
+		}, &vanguard.InterceptorOptions{
+			DenialFunc: func(_ context.Context, d *vanguard.Denial) error {
+				return status.Errorf(codes.NotFound, "%s not found", strings.Join(d.Resources, ","))
+			},
+		})
+
+		_, err := in(context.Background(), req, info, handler)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected the error from the denial func, got: %v", err)
+		}
+	})
+
+	t.Run("EvaluationError", func(t *testing.T) {
+		var denial *vanguard.Denial
+		in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+			return nil, nil
+		}, &vanguard.InterceptorOptions{
+			ErrorLogger: func(...interface{}) {},
+			DenialFunc: func(_ context.Context, d *vanguard.Denial) error {
+				denial = d
+				return status.Error(codes.InvalidArgument, "invalid resource")
+			},
+		})
+
+		// resource fails to evaluate for the '..' segment
+		req := &expb.GetExampleRevisionRequest{Parent: "1", Example: "..", Revision: "1"}
+		_, err := in(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: Service + "/GetExampleRevision"}, handler)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected the error from the denial func, got: %v", err)
+		}
+
+		if denial == nil || denial.Err == nil {
+			t.Fatalf("expected a denial with the evaluation error, got: %+v", denial)
+		}
+	})
+}
+
+func TestInterceptorDecision(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	perms := []*pb.Permission{
+		{Level: Viewer, Resources: []string{"/parents/12422/**"}},
+		{Level: Editor, Resources: []string{"/parents/12423/**"}},
+	}
+	in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return perms, nil
+	}, nil)
+
+	var d *vanguard.Decision
+	_, err = in(context.Background(), &expb.CreateExampleRequest{Parent: "/parents/12423"}, &grpc.UnaryServerInfo{FullMethod: Create}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		d, _ = vanguard.DecisionFromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d == nil {
+		t.Fatalf("expected a decision in the context")
+	}
+
+	if d.Method != Create || !d.Allowed || d.Time.IsZero() {
+		t.Fatalf("unexpected decision: %+v", d)
+	}
+
+	if len(d.Permissions) != 2 || len(d.Matched) != 1 || d.Matched[0] != perms[1] {
+		t.Fatalf("unexpected permissions in decision: %+v", d)
+	}
+
+	_, err = in(context.Background(), &expb.ListExamplesRequest{}, &grpc.UnaryServerInfo{FullMethod: ListPublic}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if _, ok := vanguard.DecisionFromContext(ctx); ok {
+			t.Fatalf("public methods should not have a decision")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInterceptorMetadata(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}
+
+	testcases := []struct {
+		Name string
+		MD   metadata.MD
+		Keys []string
+		Code codes.Code
+	}{
+		{Name: "Allowed", MD: metadata.Pairs("x-tenant-id", "acme"), Code: codes.OK},
+		{Name: "Mismatch", MD: metadata.Pairs("x-tenant-id", "other"), Code: codes.PermissionDenied},
+		{Name: "Missing", Code: codes.PermissionDenied},
+		{Name: "Restricted", MD: metadata.Pairs("x-tenant-id", "acme"), Keys: []string{"authorization"}, Code: codes.PermissionDenied},
+		{Name: "Exposed", MD: metadata.Pairs("x-tenant-id", "acme"), Keys: []string{"X-Tenant-Id"}, Code: codes.OK},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			in := vanguard.Interceptor(store, pf, &vanguard.InterceptorOptions{MetadataKeys: tc.Keys})
+			ctx := context.Background()
+			if tc.MD != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.MD)
+			}
+
+			_, err := in(ctx, &expb.ListExamplesRequest{Parent: "tenants/acme"}, &grpc.UnaryServerInfo{FullMethod: Search}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+		})
+	}
+}
+
+func TestInterceptorPeer(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}, nil)
+
+	tlsPeer := func(uris ...string) *peer.Peer {
+		cert := &x509.Certificate{}
+		for _, u := range uris {
+			pu, err := url.Parse(u)
+			if err != nil {
+				t.Fatalf("invalid uri: %v", err)
+			}
+			cert.URIs = append(cert.URIs, pu)
+		}
+
+		return &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242},
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+			},
+		}
+	}
+
+	testcases := []struct {
+		Name string
+		Peer *peer.Peer
+		Code codes.Code
+	}{
+		{Name: "NoPeer", Code: codes.PermissionDenied},
+		{Name: "Insecure", Peer: &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242}}, Code: codes.PermissionDenied},
+		{Name: "Spiffe", Peer: tlsPeer("spiffe://example.org/sync"), Code: codes.OK},
+		{Name: "OtherSpiffe", Peer: tlsPeer("spiffe://example.org/billing"), Code: codes.PermissionDenied},
+		{Name: "MultipleSpiffe", Peer: tlsPeer("spiffe://example.org/sync", "spiffe://example.org/billing"), Code: codes.PermissionDenied},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.Peer != nil {
+				ctx = peer.NewContext(ctx, tc.Peer)
+			}
+
+			_, err := in(ctx, &expb.ListExamplesRequest{Parent: "/parents/12422"}, &grpc.UnaryServerInfo{FullMethod: Sync}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+		})
+	}
+}
+
+func TestInterceptorSubject(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}
+
+	testcases := []struct {
+		Name        string
+		SubjectFunc vanguard.SubjectFunc
+		Code        codes.Code
+	}{
+		{Name: "NoSubjectFunc", Code: codes.PermissionDenied},
+		{Name: "Owner", SubjectFunc: func(context.Context) (*pb.Subject, error) {
+			return &pb.Subject{Id: "42"}, nil
+		}, Code: codes.OK},
+		{Name: "Other", SubjectFunc: func(context.Context) (*pb.Subject, error) {
+			return &pb.Subject{Id: "43"}, nil
+		}, Code: codes.PermissionDenied},
+		{Name: "Group", SubjectFunc: func(context.Context) (*pb.Subject, error) {
+			return &pb.Subject{Id: "43", Groups: []string{"admins"}}, nil
+		}, Code: codes.OK},
+		{Name: "Error", SubjectFunc: func(context.Context) (*pb.Subject, error) {
+			return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+		}, Code: codes.Unauthenticated},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			in := vanguard.Interceptor(store, pf, &vanguard.InterceptorOptions{SubjectFunc: tc.SubjectFunc})
+			_, err := in(context.Background(), &expb.GetExampleRequest{Name: "users/42/examples/1"}, &grpc.UnaryServerInfo{FullMethod: Claim}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				if d, ok := vanguard.DecisionFromContext(ctx); !ok || d.Subject == nil {
+					t.Fatalf("expected the subject in the decision")
+				}
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+		})
+	}
+}
+
+func TestInterceptorTimeBound(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	at := func(hour int) func() time.Time {
+		return func() time.Time {
+			return time.Date(2021, time.March, 1, hour, 0, 0, 0, time.UTC)
+		}
+	}
+
+	perm := &pb.Permission{
+		Level:     Manager,
+		Resources: []string{"/parents/12422/**"},
+		NotBefore: timestamppb.New(time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)),
+		NotAfter:  timestamppb.New(time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	testcases := []struct {
+		Name   string
+		Method string
+		Clock  func() time.Time
+		Code   codes.Code
+	}{
+		{Name: "BeforeValidity", Method: Delete, Clock: at(9), Code: codes.PermissionDenied},
+		{Name: "WithinValidity", Method: Delete, Clock: at(10), Code: codes.OK},
+		{Name: "AfterValidity", Method: Delete, Clock: at(12), Code: codes.PermissionDenied},
+		{Name: "WithinHours", Method: Archive, Clock: at(11), Code: codes.OK},
+		{Name: "WithinHoursAfterValidity", Method: Archive, Clock: at(13), Code: codes.PermissionDenied},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+				return []*pb.Permission{perm}, nil
+			}, &vanguard.InterceptorOptions{Clock: tc.Clock})
+
+			_, err := in(context.Background(), &expb.DeleteExampleRequest{Name: "/parents/12422/examples/1"}, &grpc.UnaryServerInfo{FullMethod: tc.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+		})
+	}
+
+	hours := &pb.Permission{Level: Manager, Resources: []string{"/parents/12422/**"}}
+	in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{hours}, nil
+	}, &vanguard.InterceptorOptions{Clock: at(18)})
+
+	_, err = in(context.Background(), &expb.DeleteExampleRequest{Name: "/parents/12422/examples/1"}, &grpc.UnaryServerInfo{FullMethod: Archive}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied outside of hours, got: %v", err)
+	}
+}
+
+func TestInterceptorCondition(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	testcases := []struct {
+		Name        string
+		Permissions []*pb.Permission
+		Code        codes.Code
+		Logged      bool
+	}{
+		{
+			Name:        "Holds",
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Condition: "r.name.endsWith('/1')"}},
+			Code:        codes.OK,
+		},
+		{
+			Name:        "DoesNotHold",
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Condition: "r.name.endsWith('/2')"}},
+			Code:        codes.PermissionDenied,
+		},
+		{
+			Name: "DenyHolds",
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/12422/**"}, Condition: "r.name.endsWith('/1')"},
+			},
+			Code: codes.PermissionDenied,
+		},
+		{
+			Name: "DenyDoesNotHold",
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/12422/**"}, Condition: "r.name.endsWith('/2')"},
+			},
+			Code: codes.OK,
+		},
+		{
+			Name:        "InvalidCondition",
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Condition: "r.unknown == 1"}},
+			Code:        codes.PermissionDenied,
+			Logged:      true,
+		},
+		{
+			Name:        "NotBool",
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Condition: "r.name"}},
+			Code:        codes.PermissionDenied,
+			Logged:      true,
+		},
+		{
+			Name: "InvalidDenyCondition",
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/12422/**"}, Condition: "r.unknown == 1"},
+			},
+			Code:   codes.PermissionDenied,
+			Logged: true,
+		},
+		{
+			Name: "OtherResource",
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/1/**"}, Condition: "r.unknown == 1"},
+			},
+			Code: codes.OK,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var logged bool
+			in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+				return tc.Permissions, nil
+			}, &vanguard.InterceptorOptions{ErrorLogger: func(...interface{}) { logged = true }})
+
+			_, err := in(context.Background(), &expb.DeleteExampleRequest{Name: "/parents/12422/examples/1"}, &grpc.UnaryServerInfo{FullMethod: Delete}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+
+			if logged != tc.Logged {
+				t.Errorf("expected logged: %v, got: %v", tc.Logged, logged)
+			}
+		})
+	}
+}
+
+func TestInterceptorMethods(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	testcases := []struct {
+		Name        string
+		Method      string
+		Permissions []*pb.Permission
+		Code        codes.Code
+		Logged      bool
+	}{
+		{
+			Name:        "Matches",
+			Method:      Get,
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Methods: []string{Service + "/Get*"}}},
+			Code:        codes.OK,
+		},
+		{
+			Name:        "DoesNotMatch",
+			Method:      Delete,
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Methods: []string{Service + "/Get*"}}},
+			Code:        codes.PermissionDenied,
+		},
+		{
+			Name:   "DenyMatches",
+			Method: Delete,
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/12422/**"}, Methods: []string{Service + "/Delete*"}},
+			},
+			Code: codes.PermissionDenied,
+		},
+		{
+			Name:   "DenyDoesNotMatch",
+			Method: Get,
+			Permissions: []*pb.Permission{
+				{Level: Manager, Resources: []string{"/parents/12422/**"}},
+				{Effect: pb.Effect_DENY, Resources: []string{"/parents/12422/**"}, Methods: []string{Service + "/Delete*"}},
+			},
+			Code: codes.OK,
+		},
+		{
+			Name:        "InvalidPattern",
+			Method:      Get,
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/parents/12422/**"}, Methods: []string{"["}}},
+			Code:        codes.PermissionDenied,
+			Logged:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var logged bool
+			in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+				return tc.Permissions, nil
+			}, &vanguard.InterceptorOptions{ErrorLogger: func(...interface{}) { logged = true }})
+
+			var req interface{} = &expb.DeleteExampleRequest{Name: "/parents/12422/examples/1"}
+			if tc.Method == Get {
+				req = &expb.GetExampleRequest{Name: "/parents/12422/examples/1"}
+			}
+
+			_, err := in(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: tc.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+
+			if logged != tc.Logged {
+				t.Errorf("expected logged: %v, got: %v", tc.Logged, logged)
+			}
+		})
+	}
+}
+
+func TestInterceptorLevelOn(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	testcases := []struct {
+		Name        string
+		Permissions []*pb.Permission
+		Code        codes.Code
+	}{
+		{Name: "Owner", Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/parents/12422/**"}}}, Code: codes.OK},
+		{Name: "Editor", Permissions: []*pb.Permission{{Level: Editor, Resources: []string{"/parents/12422/**"}}}, Code: codes.PermissionDenied},
+		{Name: "NoPermission", Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/parents/1/**"}}}, Code: codes.PermissionDenied},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var (
+				logged bool
+				denial *vanguard.Denial
+			)
+			in := vanguard.Interceptor(store, func(context.Context) ([]*pb.Permission, error) {
+				return tc.Permissions, nil
+			}, &vanguard.InterceptorOptions{
+				ErrorLogger: func(...interface{}) { logged = true },
+				DenialFunc: func(ctx context.Context, d *vanguard.Denial) error {
+					denial = d
+					return vanguard.DefaultDenial(ctx, d)
+				},
+			})
+
+			_, err := in(context.Background(), &expb.GetExampleRequest{Name: "/parents/12422/examples/1"}, &grpc.UnaryServerInfo{FullMethod: Share}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tc.Code {
+				t.Fatalf("expected code %v, got: %v", tc.Code, err)
+			}
+
+			if logged {
+				t.Error("expected the denial to not be logged")
+			}
+
+			if tc.Name != "NoPermission" {
+				return
+			}
+
+			if denial == nil || !reflect.DeepEqual(denial.Resources, []string{"/parents/12422/examples/1"}) {
+				t.Fatalf("expected the denial to have the resource, got: %+v", denial)
+			}
+
+			exp := map[string]string{"method": Share, "resources": "/parents/12422/examples/1"}
+			if ei, ok := status.Convert(err).Details()[0].(*errdetails.ErrorInfo); !ok || !reflect.DeepEqual(ei.Metadata, exp) {
+				t.Errorf("expected the error info to not have a level, got: %v", status.Convert(err).Details())
+			}
+		})
+	}
+}
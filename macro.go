@@ -0,0 +1,190 @@
+package vanguard
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/parser"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// packageMacros returns the macros declared using the (vanguard.macro) file option against their package
+func packageMacros() (map[protoreflect.FullName][]parser.Macro, error) {
+	var (
+		me       = MultiError{}
+		declared = map[protoreflect.FullName][]*pb.Macro{}
+	)
+
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		ms := proto.GetExtension(fd.Options(), pb.E_Macro).([]*pb.Macro)
+		declared[fd.Package()] = append(declared[fd.Package()], ms...)
+		return true
+	})
+
+	macros := make(map[protoreflect.FullName][]parser.Macro, len(declared))
+	for pkg, ms := range declared {
+		pms, err := newMacros(ms)
+		if err != nil {
+			me = append(me, fmt.Errorf("vanguard: invalid macros in package: %s, err: %w", pkg, err))
+			continue
+		}
+
+		macros[pkg] = pms
+	}
+
+	if len(me) > 0 {
+		return nil, me
+	}
+
+	return macros, nil
+}
+
+// newMacros parses the exprs of ms and converts them to cel macros
+func newMacros(ms []*pb.Macro) ([]parser.Macro, error) {
+	var (
+		me     = MultiError{}
+		seen   = map[string]bool{}
+		macros = make([]parser.Macro, 0, len(ms))
+	)
+
+	for _, m := range ms {
+		if m.Name == "" {
+			me = append(me, fmt.Errorf("vanguard: macro without a name, expr: %s", m.Expr))
+			continue
+		}
+
+		if seen[m.Name] {
+			me = append(me, fmt.Errorf("vanguard: macro: %s, is declared more than once", m.Name))
+			continue
+		}
+		seen[m.Name] = true
+
+		params := map[string]bool{}
+		for _, p := range m.Params {
+			if params[p] {
+				me = append(me, fmt.Errorf("vanguard: macro: %s, has duplicate param: %s", m.Name, p))
+			}
+			params[p] = true
+		}
+
+		parsed, errs := parser.Parse(common.NewTextSource(m.Expr))
+		if len(errs.GetErrors()) > 0 {
+			me = append(me, fmt.Errorf("vanguard: unable to parse macro: %s, err: %s", m.Name, errs.ToDisplayString()))
+			continue
+		}
+
+		macros = append(macros, parser.NewGlobalMacro(m.Name, len(m.Params), expander(parsed.GetExpr(), m.Params)))
+	}
+
+	if len(me) > 0 {
+		return nil, me
+	}
+
+	return macros, nil
+}
+
+// expander returns a MacroExpander that substitutes params in body with the arguments of the call
+func expander(body *exprpb.Expr, params []string) parser.MacroExpander {
+	return func(eh parser.ExprHelper, _ *exprpb.Expr, args []*exprpb.Expr) (*exprpb.Expr, *common.Error) {
+		bound := make(map[string]*exprpb.Expr, len(params))
+		for i, p := range params {
+			bound[p] = args[i]
+		}
+
+		return expand(eh, body, bound), nil
+	}
+}
+
+// expand returns a copy of e with the identifiers in bound substituted.
+// Every node of the copy gets a new id, including the substituted arguments as they can be used more than once.
+func expand(eh parser.ExprHelper, e *exprpb.Expr, bound map[string]*exprpb.Expr) *exprpb.Expr {
+	if ident := e.GetIdentExpr(); ident != nil {
+		if arg, ok := bound[ident.Name]; ok {
+			return expand(eh, arg, nil)
+		}
+	}
+
+	c := &exprpb.Expr{Id: nextID(eh)}
+	switch k := e.ExprKind.(type) {
+	case *exprpb.Expr_ConstExpr:
+		c.ExprKind = &exprpb.Expr_ConstExpr{ConstExpr: proto.Clone(k.ConstExpr).(*exprpb.Constant)}
+	case *exprpb.Expr_IdentExpr:
+		// the checker rewrites the names of identifiers in place, so they cannot be shared between expansions
+		c.ExprKind = &exprpb.Expr_IdentExpr{IdentExpr: &exprpb.Expr_Ident{Name: k.IdentExpr.Name}}
+	case *exprpb.Expr_SelectExpr:
+		c.ExprKind = &exprpb.Expr_SelectExpr{SelectExpr: &exprpb.Expr_Select{
+			Operand:  expand(eh, k.SelectExpr.Operand, bound),
+			Field:    k.SelectExpr.Field,
+			TestOnly: k.SelectExpr.TestOnly,
+		}}
+	case *exprpb.Expr_CallExpr:
+		call := &exprpb.Expr_Call{Function: k.CallExpr.Function}
+		if k.CallExpr.Target != nil {
+			call.Target = expand(eh, k.CallExpr.Target, bound)
+		}
+		for _, a := range k.CallExpr.Args {
+			call.Args = append(call.Args, expand(eh, a, bound))
+		}
+		c.ExprKind = &exprpb.Expr_CallExpr{CallExpr: call}
+	case *exprpb.Expr_ListExpr:
+		list := &exprpb.Expr_CreateList{}
+		for _, el := range k.ListExpr.Elements {
+			list.Elements = append(list.Elements, expand(eh, el, bound))
+		}
+		c.ExprKind = &exprpb.Expr_ListExpr{ListExpr: list}
+	case *exprpb.Expr_StructExpr:
+		st := &exprpb.Expr_CreateStruct{MessageName: k.StructExpr.MessageName}
+		for _, en := range k.StructExpr.Entries {
+			entry := &exprpb.Expr_CreateStruct_Entry{Id: nextID(eh), Value: expand(eh, en.Value, bound)}
+			switch key := en.KeyKind.(type) {
+			case *exprpb.Expr_CreateStruct_Entry_FieldKey:
+				entry.KeyKind = key
+			case *exprpb.Expr_CreateStruct_Entry_MapKey:
+				entry.KeyKind = &exprpb.Expr_CreateStruct_Entry_MapKey{MapKey: expand(eh, key.MapKey, bound)}
+			}
+			st.Entries = append(st.Entries, entry)
+		}
+		c.ExprKind = &exprpb.Expr_StructExpr{StructExpr: st}
+	case *exprpb.Expr_ComprehensionExpr:
+		comp := k.ComprehensionExpr
+		// the variables of the comprehension shadow the params
+		inner := shadow(bound, comp.IterVar, comp.AccuVar)
+		c.ExprKind = &exprpb.Expr_ComprehensionExpr{ComprehensionExpr: &exprpb.Expr_Comprehension{
+			IterVar:       comp.IterVar,
+			IterRange:     expand(eh, comp.IterRange, bound),
+			AccuVar:       comp.AccuVar,
+			AccuInit:      expand(eh, comp.AccuInit, bound),
+			LoopCondition: expand(eh, comp.LoopCondition, inner),
+			LoopStep:      expand(eh, comp.LoopStep, inner),
+			Result:        expand(eh, comp.Result, inner),
+		}}
+	}
+
+	return c
+}
+
+// nextID returns a new expression id at the location of the macro call
+func nextID(eh parser.ExprHelper) int64 {
+	return eh.LiteralBool(false).Id
+}
+
+func shadow(bound map[string]*exprpb.Expr, names ...string) map[string]*exprpb.Expr {
+	if len(bound) == 0 {
+		return bound
+	}
+
+	inner := make(map[string]*exprpb.Expr, len(bound))
+	for k, v := range bound {
+		inner[k] = v
+	}
+
+	for _, n := range names {
+		delete(inner, n)
+	}
+
+	return inner
+}
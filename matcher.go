@@ -1,7 +1,9 @@
 package vanguard
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -25,6 +27,7 @@ type ResourceMatcher interface {
 // * Exact
 // * Ordered
 // * BitMask
+// * Graph
 type LevelMatcher interface {
 	MatchLevel(has, required int64) bool
 }
@@ -59,6 +62,16 @@ func (rm *RegexResourceMatcher) MatchResource(pattern, resource string) (bool, e
 	return expr.MatchString(resource), nil
 }
 
+// EscapePattern escapes value using DefaultEscapeResource and quotes the regex metacharacters in it
+func (*RegexResourceMatcher) EscapePattern(value string) (string, error) {
+	ev, err := DefaultEscapeResource(value)
+	if err != nil {
+		return "", err
+	}
+
+	return regexp.QuoteMeta(ev), nil
+}
+
 // RegexResourceMatcher matches if the resource has the pattern as prefix
 type PrefixResourceMatcher struct{}
 
@@ -103,7 +116,7 @@ func (rm *GlobResourceMatcher) MatchResource(pattern, resource string) (bool, er
 type ExactLevelMatcher struct {
 }
 
-func (*ExactLevelMatcher) MatchLevel(has, needs int) bool {
+func (*ExactLevelMatcher) MatchLevel(has, needs int64) bool {
 	return has == needs
 }
 
@@ -125,6 +138,103 @@ func (o *OrderedLevelMatcher) MatchLevel(has, needs int64) bool {
 type BitMaskLevelMatcher struct {
 }
 
-func (*BitMaskLevelMatcher) MatchLevel(has, needs int) bool {
+func (*BitMaskLevelMatcher) MatchLevel(has, needs int64) bool {
 	return has&needs == needs
 }
+
+// GraphLevelMatcher matches if the levels are equal or if the level implies the needed level, directly or transitively.
+// It is used when the levels are neither totally ordered nor flags, Eg: BILLING_ADMIN implies BILLING_VIEWER but not EDITOR.
+//
+// Use NewGraphLevelMatcher to build it, the implications are resolved up front so matching is a map lookup.
+type GraphLevelMatcher struct {
+	// implied holds all the levels implied by a level, transitively
+	implied map[int64]map[int64]struct{}
+}
+
+// NewGraphLevelMatcher builds a GraphLevelMatcher from implies, the levels that are directly implied by each level.
+// Eg: {OWNER: {EDITOR, BILLING_ADMIN}, EDITOR: {VIEWER}, BILLING_ADMIN: {BILLING_VIEWER}}
+//
+// It is an error if the implications have a cycle.
+func NewGraphLevelMatcher(implies map[int64][]int64) (*GraphLevelMatcher, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	var (
+		gm    = &GraphLevelMatcher{implied: make(map[int64]map[int64]struct{}, len(implies))}
+		state = make(map[int64]int, len(implies))
+		path  []int64
+		visit func(l int64) error
+	)
+	visit = func(l int64) error {
+		switch state[l] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("vanguard: cycle in level implications: %s", cycleOf(path, l))
+		}
+
+		state[l] = visiting
+		path = append(path, l)
+
+		implied := map[int64]struct{}{}
+		for _, il := range implies[l] {
+			if err := visit(il); err != nil {
+				return err
+			}
+
+			implied[il] = struct{}{}
+			for tl := range gm.implied[il] {
+				implied[tl] = struct{}{}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[l] = done
+		gm.implied[l] = implied
+		return nil
+	}
+
+	// levels are visited in order so that the reported cycle is deterministic
+	levels := make([]int64, 0, len(implies))
+	for l := range implies {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	for _, l := range levels {
+		if err := visit(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return gm, nil
+}
+
+// cycleOf formats the cycle of path that ends at l, Eg: 1 -> 5 -> 1
+func cycleOf(path []int64, l int64) string {
+	start := 0
+	for i, pl := range path {
+		if pl == l {
+			start = i
+			break
+		}
+	}
+
+	ll := make([]string, 0, len(path)-start+1)
+	for _, pl := range append(path[start:len(path):len(path)], l) {
+		ll = append(ll, fmt.Sprint(pl))
+	}
+
+	return strings.Join(ll, " -> ")
+}
+
+func (gm *GraphLevelMatcher) MatchLevel(has, needs int64) bool {
+	if has == needs {
+		return true
+	}
+
+	_, ok := gm.implied[has][needs]
+	return ok
+}
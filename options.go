@@ -1,5 +1,11 @@
 package vanguard
 
+import (
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
 const (
 	LevelOwner   = 1
 	LevelManager = 5
@@ -17,7 +23,7 @@ type Level struct {
 // DefaultLevels are only a placeholder, They can be used in a production system.
 // But typically they are overridden.
 //
-// Look at `WithRoles` to override them
+// Look at `WithRoles` and `WithRolesFromEnum` to override them
 func DefaultLevels() []Level {
 	return []Level{
 		{Name: "OWNER", Value: 1},
@@ -27,11 +33,28 @@ func DefaultLevels() []Level {
 	}
 }
 
+// LevelSet is a named list of levels. Name can be used as is in the assert expressions
+// wherever a list of levels is accepted, Eg: u.hasAny(WRITERS, [r.name])
+type LevelSet struct {
+	Name   string
+	Values []int64
+}
+
+// DefaultConditionCacheSize is the number of compiled conditions that are cached when it is not set using
+// `WithConditionCacheSize`
+const DefaultConditionCacheSize = 1024
+
 type options struct {
-	Roles []Level
+	Roles     []Level
+	LevelSets []LevelSet
 
 	ResourceMatcher ResourceMatcher
 	LevelMatcher    LevelMatcher
+
+	Declarations []*exprpb.Decl
+	Functions    []*functions.Overload
+
+	ConditionCacheSize int
 }
 
 type option func(*options)
@@ -43,6 +66,33 @@ func WithRoles(rl []Level) option {
 	}
 }
 
+// WithRolesFromEnum is used to replace the base set of roles with the values of an enum. The names of the values
+// can be used as is in the assert expressions and are substituted with their numbers.
+//
+// The zero value is skipped, as by convention it is the unspecified value.
+// Look at the Role field of Permission to store the levels using the enum.
+func WithRolesFromEnum(ed protoreflect.EnumDescriptor) option {
+	values := ed.Values()
+	rl := make([]Level, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		if v.Number() == 0 {
+			continue
+		}
+
+		rl = append(rl, Level{Name: string(v.Name()), Value: int64(v.Number())})
+	}
+
+	return WithRoles(rl)
+}
+
+// WithLevelSets adds named lists of levels that can be used in the assert expressions
+func WithLevelSets(ls []LevelSet) option {
+	return func(o *options) {
+		o.LevelSets = ls
+	}
+}
+
 // WithResourceMatcher can be used to replace the resource matching strategies
 //
 // List of available options: Exact, Prefix, Regex, and Glob
@@ -60,3 +110,31 @@ func WithLevelMatcher(m LevelMatcher) option {
 		o.LevelMatcher = m
 	}
 }
+
+// WithDeclarations adds cel declarations of functions and variables that can be used in the assert expressions.
+// Look at the `decls` package of cel-go to create them.
+//
+// The implementations of the functions are added using `WithFunctions`,
+// the values of the variables are provided by `InterceptorOptions.VariablesFunc`.
+func WithDeclarations(ds ...*exprpb.Decl) option {
+	return func(o *options) {
+		o.Declarations = append(o.Declarations, ds...)
+	}
+}
+
+// WithFunctions adds the implementations of the functions declared using `WithDeclarations`.
+// The Operator of an overload is the overload id of its declaration.
+func WithFunctions(fs ...*functions.Overload) option {
+	return func(o *options) {
+		o.Functions = append(o.Functions, fs...)
+	}
+}
+
+// WithConditionCacheSize sets the number of compiled conditions of permissions that are cached, defaults to
+// DefaultConditionCacheSize. The least recently used conditions are evicted when it is full, so it should be
+// at least the number of distinct conditions that are in use. Caching is disabled if it is less than 1.
+func WithConditionCacheSize(n int) option {
+	return func(o *options) {
+		o.ConditionCacheSize = n
+	}
+}
@@ -0,0 +1,89 @@
+package vanguard
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// peerValue is the value of `peer` in the asserts. All the keys are always present, so that asserts
+// need not check for them. The identity is only read from verified certificate chains.
+//
+//	address: remote address of the caller
+//	verified: true if the caller presented a verified client certificate
+//	subject: subject of the leaf certificate, Eg: CN=billing,O=Example
+//	dnsNames, uris, emails, ipAddresses: SANs of the leaf certificate
+//	spiffeId: the SPIFFE ID of the caller, it is the only URI SAN with the spiffe scheme
+func peerValue(p *peer.Peer) map[string]interface{} {
+	v := map[string]interface{}{
+		"address":     "",
+		"verified":    false,
+		"subject":     "",
+		"dnsNames":    []string{},
+		"uris":        []string{},
+		"emails":      []string{},
+		"ipAddresses": []string{},
+		"spiffeId":    "",
+	}
+
+	if p == nil {
+		return v
+	}
+
+	if p.Addr != nil {
+		v["address"] = p.Addr.String()
+	}
+
+	var state tls.ConnectionState
+	switch info := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		state = info.State
+	case *credentials.TLSInfo:
+		state = info.State
+	default:
+		return v
+	}
+
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return v
+	}
+
+	leaf := state.VerifiedChains[0][0]
+	v["verified"] = true
+	v["subject"] = leaf.Subject.String()
+	v["dnsNames"] = append([]string{}, leaf.DNSNames...)
+	v["emails"] = append([]string{}, leaf.EmailAddresses...)
+
+	ips := make([]string, 0, len(leaf.IPAddresses))
+	for _, ip := range leaf.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	v["ipAddresses"] = ips
+
+	uris := make([]string, 0, len(leaf.URIs))
+	var spiffe []string
+	for _, u := range leaf.URIs {
+		uris = append(uris, u.String())
+		if u.Scheme == "spiffe" {
+			spiffe = append(spiffe, u.String())
+		}
+	}
+	v["uris"] = uris
+
+	// multiple SPIFFE IDs are invalid as per the spec, none of them are trusted
+	if len(spiffe) == 1 {
+		v["spiffeId"] = spiffe[0]
+	}
+
+	return v
+}
+
+// stringAddr is a net.Addr used for transports that only expose the remote address as a string
+type stringAddr string
+
+func (a stringAddr) Network() string { return "tcp" }
+func (a stringAddr) String() string  { return string(a) }
+
+var _ net.Addr = stringAddr("")
@@ -0,0 +1,188 @@
+package vanguard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// maxResourceValues is the maximum number of values that can be substituted by the resource function
+const maxResourceValues = 8
+
+// ResourceEscaper can be implemented by a ResourceMatcher to control how the values substituted by the
+// `resource` function are escaped. Values that cannot be used in a resource name must be rejected with an error.
+//
+// ResourceMatchers that do not implement it use DefaultEscapeResource.
+type ResourceEscaper interface {
+	EscapeResource(value string) (string, error)
+}
+
+// PatternEscaper can be implemented by a ResourceMatcher to control how the values substituted in the resource
+// patterns of a RoleRegistry are escaped, so that they only match themselves.
+//
+// ResourceMatchers that do not implement it use their ResourceEscaper or DefaultEscapeResource, which is enough for
+// the matchers whose patterns have no special characters other than the ones escaped by it, Eg: Glob, Prefix and Exact.
+type PatternEscaper interface {
+	EscapePattern(value string) (string, error)
+}
+
+// DefaultEscapeResource percent-encodes the separator `/` and the wildcard and escape characters of the matchers,
+// `* ? [ ] \`, so that they are matched literally. Eg: `a/b*` is escaped to `a%2Fb%2A`. `%` is encoded as well,
+// so that an escaped value cannot be passed in already escaped. Other characters, like spaces and non ASCII ones,
+// are kept as is. Empty values and the `.` and `..` segments are rejected.
+func DefaultEscapeResource(value string) (string, error) {
+	switch value {
+	case "":
+		return "", fmt.Errorf("vanguard: empty value in resource")
+	case ".", "..":
+		return "", fmt.Errorf("vanguard: invalid value in resource: %q", value)
+	}
+
+	if !strings.ContainsAny(value, resourceSpecials) {
+		return value, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; strings.IndexByte(resourceSpecials, c) >= 0 {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// resourceSpecials are the characters escaped by DefaultEscapeResource
+const resourceSpecials = "%/*?[]\\"
+
+// resourceDecls declares the overloads of the resource function, one for each number of values
+func resourceDecls() *exprpb.Decl {
+	overloads := make([]*exprpb.Decl_FunctionDecl_Overload, 0, maxResourceValues+1)
+	params := []*exprpb.Type{decls.String}
+	for i := 0; i <= maxResourceValues; i++ {
+		overloads = append(overloads, decls.NewOverload(resourceOverload(i), append([]*exprpb.Type{}, params...), decls.String))
+		params = append(params, decls.String)
+	}
+
+	return decls.NewFunction("resource", overloads...)
+}
+
+func resourceOverload(values int) string {
+	return "resource_string" + strings.Repeat("_string", values)
+}
+
+// resourceFuncs implements the overloads declared by resourceDecls
+func (mf matchFuncs) resourceFuncs() []*functions.Overload {
+	fs := make([]*functions.Overload, 0, maxResourceValues+1)
+	for i := 0; i <= maxResourceValues; i++ {
+		fs = append(fs, &functions.Overload{
+			Operator: resourceOverload(i),
+			Unary: func(v ref.Val) ref.Val {
+				return mf.resource(v)
+			},
+			Binary: func(lhs, rhs ref.Val) ref.Val {
+				return mf.resource(lhs, rhs)
+			},
+			Function: mf.resource,
+		})
+	}
+
+	return fs
+}
+
+// resource substitutes the placeholders of the template, values[0], with the escaped values in order.
+// Eg: resource('books/{book}/pages/{page}', r.book, r.page)
+func (mf matchFuncs) resource(values ...ref.Val) ref.Val {
+	tmpl, ok := values[0].Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(values[0])
+	}
+
+	vv := make([]string, 0, len(values)-1)
+	for _, v := range values[1:] {
+		sv, ok := v.Value().(string)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(v)
+		}
+		vv = append(vv, sv)
+	}
+
+	next := 0
+	res, err := substitute(tmpl, escaperOf(mf.rm), func(string) (string, error) {
+		if next >= len(vv) {
+			return "", fmt.Errorf("vanguard: not enough values for resource template: %s", tmpl)
+		}
+		next++
+		return vv[next-1], nil
+	})
+	if err != nil {
+		return types.NewErr(err.Error())
+	}
+
+	if next != len(vv) {
+		return types.NewErr("vanguard: too many values for resource template: %s", tmpl)
+	}
+
+	return types.String(res)
+}
+
+// escaperOf returns the function used to escape the values substituted in the resource templates for rm
+func escaperOf(rm ResourceMatcher) func(string) (string, error) {
+	if e, ok := rm.(ResourceEscaper); ok {
+		return e.EscapeResource
+	}
+
+	return DefaultEscapeResource
+}
+
+// patternEscaperOf returns the function used to escape the values substituted in the resource patterns for rm
+func patternEscaperOf(rm ResourceMatcher) func(string) (string, error) {
+	if e, ok := rm.(PatternEscaper); ok {
+		return e.EscapePattern
+	}
+
+	return escaperOf(rm)
+}
+
+// substitute replaces the placeholders of tmpl, Eg: {book}, in order with the escaped values returned by value for their names
+func substitute(tmpl string, escape, value func(string) (string, error)) (string, error) {
+	var (
+		sb   strings.Builder
+		rest = tmpl
+	)
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			sb.WriteString(rest)
+			break
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("vanguard: unterminated placeholder in resource template: %s", tmpl)
+		}
+
+		v, err := value(rest[start+1 : start+end])
+		if err != nil {
+			return "", err
+		}
+
+		ev, err := escape(v)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(rest[:start])
+		sb.WriteString(ev)
+		rest = rest[start+end+1:]
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,220 @@
+package vanguard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/srikrsna/glob"
+	pb "github.com/srikrsna/vanguard/vanguard"
+)
+
+// PermissionTemplate is a permission whose resources can have placeholders, Eg: /books/{book}/**.
+// The placeholders are substituted with the params of a RoleBinding, escaped using the PatternEscaper of the ResourceMatcher.
+type PermissionTemplate struct {
+	Level     int64
+	Resources []string
+	Methods   []string
+	Condition string
+	Effect    pb.Effect
+}
+
+// Role is a named set of permission templates, Params are the names of the placeholders that can be used in them.
+type Role struct {
+	Name        string
+	Params      []string
+	Permissions []PermissionTemplate
+}
+
+// RoleBinding grants a role to a user with the values of its params,
+// Eg: RoleBinding{Role: "Book Owner", Params: map[string]string{"book": "1242"}}
+type RoleBinding struct {
+	Role   string
+	Params map[string]string
+}
+
+// RoleRegistry holds a set of roles and expands role bindings to permissions.
+// The roles and the expanded permissions are validated against the ResourceMatcher,
+// it must be the same one that is passed to NewVanguard.
+type RoleRegistry struct {
+	rm    ResourceMatcher
+	roles map[string]*Role
+}
+
+// NewRoleRegistry validates the roles and returns a RoleRegistry with them.
+// If rm is nil the default ResourceMatcher, GlobResourceMatcher, is used.
+func NewRoleRegistry(rm ResourceMatcher, roles ...Role) (*RoleRegistry, error) {
+	if rm == nil {
+		rm = &GlobResourceMatcher{}
+	}
+
+	var (
+		me = MultiError{}
+		rr = &RoleRegistry{rm: rm, roles: make(map[string]*Role, len(roles))}
+	)
+	for i := range roles {
+		role := &roles[i]
+		if role.Name == "" {
+			me = append(me, fmt.Errorf("vanguard: role without a name"))
+			continue
+		}
+
+		if _, ok := rr.roles[role.Name]; ok {
+			me = append(me, fmt.Errorf("vanguard: role: %s, is declared more than once", role.Name))
+			continue
+		}
+
+		if err := rr.validate(role); err != nil {
+			me = append(me, fmt.Errorf("vanguard: invalid role: %s, err: %w", role.Name, err))
+			continue
+		}
+
+		rr.roles[role.Name] = role
+	}
+
+	if len(me) > 0 {
+		return nil, me
+	}
+
+	return rr, nil
+}
+
+// validate checks that the templates of role only use its params and that they are valid patterns
+// for the ResourceMatcher, the params are substituted with their names to do so.
+func (rr *RoleRegistry) validate(role *Role) error {
+	me := MultiError{}
+	params := make(map[string]string, len(role.Params))
+	for _, p := range role.Params {
+		if p == "" {
+			me = append(me, fmt.Errorf("vanguard: empty param"))
+		} else if _, ok := params[p]; ok {
+			me = append(me, fmt.Errorf("vanguard: duplicate param: %s", p))
+		}
+		params[p] = p
+	}
+
+	if len(role.Permissions) == 0 {
+		me = append(me, fmt.Errorf("vanguard: role has no permissions"))
+	}
+
+	for _, pt := range role.Permissions {
+		if len(pt.Resources) == 0 {
+			me = append(me, fmt.Errorf("vanguard: permission template without resources"))
+		}
+
+		if _, err := rr.permission(&pt, params); err != nil {
+			me = append(me, err)
+		}
+	}
+
+	if len(me) > 0 {
+		return me
+	}
+
+	return nil
+}
+
+// Expand returns the permissions granted by the bindings. It is an error if a role is unknown, if a param of a
+// role is missing or unknown, or if a value cannot be used in a resource.
+func (rr *RoleRegistry) Expand(bindings ...RoleBinding) ([]*Permission, error) {
+	var (
+		me    = MultiError{}
+		perms = make([]*Permission, 0, len(bindings))
+	)
+	for _, b := range bindings {
+		role, ok := rr.roles[b.Role]
+		if !ok {
+			me = append(me, fmt.Errorf("vanguard: unknown role: %s", b.Role))
+			continue
+		}
+
+		if err := checkParams(role, b.Params); err != nil {
+			me = append(me, err)
+			continue
+		}
+
+		for _, pt := range role.Permissions {
+			perm, err := rr.permission(&pt, b.Params)
+			if err != nil {
+				me = append(me, fmt.Errorf("vanguard: unable to expand role: %s, err: %w", b.Role, err))
+				continue
+			}
+
+			perms = append(perms, perm)
+		}
+	}
+
+	if len(me) > 0 {
+		return nil, me
+	}
+
+	return perms, nil
+}
+
+// PermissionsFunc returns a PermissionsFunc that expands the role bindings returned by bf
+func (rr *RoleRegistry) PermissionsFunc(bf func(context.Context) ([]RoleBinding, error)) PermissionsFunc {
+	return func(ctx context.Context) ([]*Permission, error) {
+		bindings, err := bf(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return rr.Expand(bindings...)
+	}
+}
+
+// checkParams reports an error if params does not have exactly the params of role
+func checkParams(role *Role, params map[string]string) error {
+	declared := make(map[string]bool, len(role.Params))
+	for _, p := range role.Params {
+		declared[p] = true
+		if _, ok := params[p]; !ok {
+			return fmt.Errorf("vanguard: missing param: %s, for role: %s", p, role.Name)
+		}
+	}
+
+	for p := range params {
+		if !declared[p] {
+			return fmt.Errorf("vanguard: unknown param: %s, for role: %s", p, role.Name)
+		}
+	}
+
+	return nil
+}
+
+// permission substitutes the params in the resources of pt and checks that they are valid patterns
+func (rr *RoleRegistry) permission(pt *PermissionTemplate, params map[string]string) (*Permission, error) {
+	perm := &Permission{
+		Level:     pt.Level,
+		Resources: make([]string, 0, len(pt.Resources)),
+		Methods:   append([]string(nil), pt.Methods...),
+		Condition: pt.Condition,
+		Effect:    pt.Effect,
+	}
+
+	for _, tmpl := range pt.Resources {
+		res, err := substitute(tmpl, patternEscaperOf(rr.rm), func(name string) (string, error) {
+			v, ok := params[name]
+			if !ok {
+				return "", fmt.Errorf("vanguard: unknown param: %s, in resource template: %s", name, tmpl)
+			}
+			return v, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := rr.rm.MatchResource(res, ""); err != nil {
+			return nil, fmt.Errorf("vanguard: invalid resource pattern: %s, err: %w", res, err)
+		}
+
+		perm.Resources = append(perm.Resources, res)
+	}
+
+	for _, p := range perm.Methods {
+		if _, err := glob.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("vanguard: invalid method pattern: %s, err: %w", p, err)
+		}
+	}
+
+	return perm, nil
+}
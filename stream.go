@@ -0,0 +1,146 @@
+package vanguard
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"google.golang.org/grpc"
+)
+
+// StreamInterceptor is grpc StreamServerInterceptor that asserts that a caller has permission to access streaming endpoints.
+//
+// For server streaming methods the assert is evaluated against the first message received from the client,
+// before it is handed over to the handler.
+// For client and bidi streaming methods the assert_each is evaluated against every message received, the permissions
+// are only retrieved once per stream.
+//
+// pf is called once per stream with the incoming context, when the first message is received.
+func StreamInterceptor(store Vanguard, pf PermissionsFunc, opt *InterceptorOptions) grpc.StreamServerInterceptor {
+	opt = opt.withDefaults()
+	if opt.Skip {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, ss)
+		}
+	}
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		assert, err := opt.lookup(ss.Context(), store, info.FullMethod)
+		if err != nil {
+			return err
+		} else if assert == nil {
+			return handler(srv, ss)
+		}
+
+		ws := &serverStream{
+			ServerStream: ss,
+			streamGuard: streamGuard{
+				method: info.FullMethod,
+				assert: assert,
+				each:   info.IsClientStream,
+				pf:     pf,
+				opt:    opt,
+			},
+		}
+		defer ws.release()
+
+		return handler(srv, ws)
+	}
+}
+
+// streamGuard evaluates the assert of a stream against its messages.
+// The permissions are retrieved once per stream.
+type streamGuard struct {
+	method string
+	assert cel.Program
+	// each evaluates the assert against every message, instead of only the first one
+	each bool
+	pf   PermissionsFunc
+	opt  *InterceptorOptions
+
+	// vars is set once the first message is checked and reused for the rest of the stream
+	vars     *activation
+	decision *Decision
+	err      error
+}
+
+// check evaluates the assert against m.
+// Once a message is denied, the same error is returned for the rest of the stream.
+func (g *streamGuard) check(ctx context.Context, m interface{}) error {
+	if g.err != nil || (g.vars != nil && !g.each) {
+		return g.err
+	}
+
+	if g.vars == nil {
+		vars, err := g.opt.activation(ctx, g.method, g.pf)
+		if err != nil {
+			g.err = g.opt.enforce(ctx, g.method, err)
+			return g.err
+		}
+
+		g.vars = vars
+	}
+
+	g.vars.R = m
+	d, err := evaluate(ctx, g.method, g.assert, g.vars, g.opt)
+	if g.err = g.opt.enforce(ctx, g.method, err); g.err == nil && d != nil {
+		g.decision = d
+	}
+
+	return g.err
+}
+
+func (g *streamGuard) release() {
+	if g.vars != nil {
+		varPool.Put(g.vars)
+		g.vars = nil
+	}
+}
+
+// serverStream wraps a grpc.ServerStream and asserts on the received messages.
+type serverStream struct {
+	grpc.ServerStream
+	streamGuard
+
+	// ctx carries the decision of the last evaluation, it is guarded by mu as
+	// Context can be called concurrently with RecvMsg
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil {
+		return s.ctx
+	}
+
+	return s.ServerStream.Context()
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	last := s.decision
+	if err := s.check(s.ServerStream.Context(), m); err != nil {
+		return err
+	}
+
+	if s.decision != last {
+		ctx := withDecision(s.ServerStream.Context(), s.decision)
+		s.mu.Lock()
+		s.ctx = ctx
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+var _ grpc.ServerStream = (*serverStream)(nil)
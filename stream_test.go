@@ -0,0 +1,185 @@
+package vanguard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	Watch  = Service + "/WatchExamples"
+	Import = Service + "/ImportExamples"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+
+	msgs []proto.Message
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return status.Error(codes.Canceled, "no more messages")
+	}
+
+	proto.Merge(m.(proto.Message), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	perms := []*pb.Permission{
+		{Level: Viewer, Resources: []string{"/parents/12422/**"}},
+	}
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return perms, nil
+	}
+
+	testcases := []struct {
+		Name   string
+		Parent string
+		Code   codes.Code
+	}{
+		{Name: "Allow", Parent: "/parents/12422", Code: codes.OK},
+		{Name: "Deny", Parent: "/parents/12423", Code: codes.PermissionDenied},
+	}
+
+	si := vanguard.StreamInterceptor(store, pf, nil)
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			ss := &fakeServerStream{
+				msgs: []proto.Message{&expb.ListExamplesRequest{Parent: tc.Parent}},
+			}
+
+			called := false
+			err := si(nil, ss, &grpc.StreamServerInfo{FullMethod: Watch, IsServerStream: true}, func(srv interface{}, ss grpc.ServerStream) error {
+				var req expb.ListExamplesRequest
+				if err := ss.RecvMsg(&req); err != nil {
+					return err
+				}
+
+				if _, ok := vanguard.DecisionFromContext(ss.Context()); !ok {
+					t.Fatalf("expected a decision in the stream context")
+				}
+
+				called = true
+				return nil
+			})
+
+			if status.Code(err) != tc.Code {
+				t.Fatalf("code mismatch, exp: %v, act: %v", tc.Code, status.Code(err))
+			}
+
+			if called != (tc.Code == codes.OK) {
+				t.Fatalf("handler saw the request message: %v", called)
+			}
+		})
+	}
+}
+
+func TestStreamInterceptorEach(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	calls := 0
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		calls++
+		return []*pb.Permission{
+			{Level: Editor, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}
+
+	ss := &fakeServerStream{
+		msgs: []proto.Message{
+			&expb.CreateExampleRequest{Parent: "/parents/12422"},
+			&expb.CreateExampleRequest{Parent: "/parents/12422"},
+			&expb.CreateExampleRequest{Parent: "/parents/12423"},
+			&expb.CreateExampleRequest{Parent: "/parents/12422"},
+		},
+	}
+
+	received := 0
+	si := vanguard.StreamInterceptor(store, pf, nil)
+	err = si(nil, ss, &grpc.StreamServerInfo{FullMethod: Import, IsClientStream: true}, func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			var req expb.CreateExampleRequest
+			if err := ss.RecvMsg(&req); err != nil {
+				return err
+			}
+			received++
+		}
+	})
+
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied, got: %v", err)
+	}
+
+	if received != 2 {
+		t.Fatalf("expected the handler to receive 2 messages, got: %d", received)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected permissions to be retrieved once, got: %d", calls)
+	}
+}
+
+func TestStreamInterceptorConcurrentContext(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	pf := func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: Editor, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}
+
+	var msgs []proto.Message
+	for i := 0; i < 100; i++ {
+		msgs = append(msgs, &expb.CreateExampleRequest{Parent: "/parents/12422"})
+	}
+
+	si := vanguard.StreamInterceptor(store, pf, nil)
+	err = si(nil, &fakeServerStream{msgs: msgs}, &grpc.StreamServerInfo{FullMethod: Import, IsClientStream: true}, func(srv interface{}, ss grpc.ServerStream) error {
+		done := make(chan struct{})
+		defer func() { <-done }()
+
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				vanguard.DecisionFromContext(ss.Context())
+			}
+		}()
+
+		for {
+			var req expb.CreateExampleRequest
+			if err := ss.RecvMsg(&req); err != nil {
+				return err
+			}
+		}
+	})
+
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("expected the stream to end, got: %v", err)
+	}
+}
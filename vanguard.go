@@ -4,12 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
 	"github.com/google/cel-go/interpreter/functions"
+	"github.com/google/cel-go/parser"
+	"github.com/srikrsna/glob"
 	pb "github.com/srikrsna/vanguard/vanguard"
 	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	"google.golang.org/protobuf/proto"
@@ -19,8 +23,11 @@ import (
 
 type Permission = pb.Permission
 
+type Subject = pb.Subject
+
 // Vanguard holds all the compiled assert expressions against the fully qualified
-// method name.
+// method name. For client streaming methods it holds the assert_each expression.
+// The service_assert and file_assert are combined with the method's assert.
 //
 // Example for key: /package.Service/Method
 // Look at `NewVanguard` to see how it can be created
@@ -38,6 +45,8 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 			Roles:           DefaultLevels(),
 			ResourceMatcher: &GlobResourceMatcher{},
 			LevelMatcher:    &OrderedLevelMatcher{},
+
+			ConditionCacheSize: DefaultConditionCacheSize,
 		}
 	)
 
@@ -47,16 +56,27 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 
 	// Global Types
 	permSliceType := decls.NewListType(decls.NewObjectType(string((&pb.Permission{}).ProtoReflect().Descriptor().FullName())))
-	var gds []*exprpb.Decl
+	// cds are the declarations available to both the asserts and the conditions of the permissions
+	var cds []*exprpb.Decl
 	roleType := decls.NewPrimitiveType(exprpb.Type_INT64)
 	for _, r := range opt.Roles {
 		d := decls.NewConst(r.Name, roleType, &exprpb.Constant{
 			ConstantKind: &exprpb.Constant_Int64Value{Int64Value: r.Value},
 		})
-		gds = append(gds, d)
+		cds = append(cds, d)
 	}
+	for _, ls := range opt.LevelSets {
+		cds = append(cds, decls.NewVar(ls.Name, decls.NewListType(roleType)))
+	}
+	cds = append(cds, decls.NewVar("s", decls.NewObjectType(string((&pb.Subject{}).ProtoReflect().Descriptor().FullName()))))
+	cds = append(cds, decls.NewVar("md", decls.NewMapType(decls.String, decls.NewListType(decls.String))))
+	cds = append(cds, decls.NewVar("peer", decls.NewMapType(decls.String, decls.Dyn)))
+	cds = append(cds, decls.NewVar("now", decls.Timestamp))
+	cds = append(cds, resourceDecls())
+	cds = append(cds, opt.Declarations...)
+
+	gds := append([]*exprpb.Decl{}, cds...)
 	gds = append(gds, decls.NewVar("u", permSliceType))
-
 	gds = append(gds,
 		// Functions
 		decls.NewFunction(
@@ -70,6 +90,15 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 				},
 				decls.Bool,
 			),
+			decls.NewInstanceOverload(
+				"user_any_levels_resources",
+				[]*exprpb.Type{
+					permSliceType,
+					decls.NewListType(roleType),
+					decls.NewListType(decls.String),
+				},
+				decls.Bool,
+			),
 		),
 		decls.NewFunction(
 			"hasAll",
@@ -82,21 +111,89 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 				},
 				decls.Bool,
 			),
+			decls.NewInstanceOverload(
+				"user_all_levels_resources",
+				[]*exprpb.Type{
+					permSliceType,
+					decls.NewListType(roleType),
+					decls.NewListType(decls.String),
+				},
+				decls.Bool,
+			),
+		),
+		decls.NewFunction(
+			"levelOn",
+			decls.NewInstanceOverload(
+				"user_level_on_resource",
+				[]*exprpb.Type{
+					permSliceType,
+					decls.String,
+				},
+				roleType,
+			),
+		),
+		decls.NewFunction(
+			"resourcesWith",
+			decls.NewInstanceOverload(
+				"user_resources_with_level",
+				[]*exprpb.Type{
+					permSliceType,
+					roleType,
+				},
+				decls.NewListType(decls.String),
+			),
 		),
 	)
 
-	mf := matchFuncs{rm: opt.ResourceMatcher, lm: opt.LevelMatcher}
+	mf := matchFuncs{rm: opt.ResourceMatcher, lm: opt.LevelMatcher, levels: map[int64]string{}, conds: &conditions{decls: cds, cache: newLRUCache(opt.ConditionCacheSize)}}
+	for _, r := range opt.Roles {
+		if _, ok := mf.levels[r.Value]; !ok {
+			mf.levels[r.Value] = r.Name
+		}
+	}
 
-	funcs := cel.Functions(
-		&functions.Overload{
+	overloads := []*functions.Overload{
+		{
 			Operator: "user_any_level_resources",
 			Function: mf.any,
 		},
-		&functions.Overload{
+		{
 			Operator: "user_all_level_resources",
 			Function: mf.all,
 		},
-	)
+		{
+			Operator: "user_any_levels_resources",
+			Function: mf.any,
+		},
+		{
+			Operator: "user_all_levels_resources",
+			Function: mf.all,
+		},
+		{
+			Operator: "user_level_on_resource",
+			Binary:   mf.levelOn,
+		},
+		{
+			Operator: "user_resources_with_level",
+			Binary:   mf.resourcesWith,
+		},
+	}
+	overloads = append(overloads, mf.resourceFuncs()...)
+	overloads = append(overloads, opt.Functions...)
+	progOpts := []cel.ProgramOption{cel.Functions(overloads...)}
+	if len(opt.LevelSets) > 0 {
+		sets := make(map[string]interface{}, len(opt.LevelSets))
+		for _, ls := range opt.LevelSets {
+			sets[ls.Name] = ls.Values
+		}
+		progOpts = append(progOpts, cel.Globals(sets))
+	}
+	mf.conds.progOpts = progOpts
+
+	macros, err := packageMacros()
+	if err != nil {
+		return nil, err
+	}
 
 	type result struct {
 		Err  error
@@ -115,7 +212,7 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 				m := methods.Get(j)
 				count++
 				go func() {
-					prg, err := compile(s, m, gds, funcs)
+					prg, err := compile(s, m, gds, macros[fd.Package()], progOpts...)
 					results <- &result{
 						Prg:  prg,
 						Name: "/" + string(s.FullName()) + "/" + string(m.Name()),
@@ -150,17 +247,46 @@ func NewVanguard(opts ...option) (Vanguard, error) {
 
 var errSkip = errors.New("skip error")
 
+// Public is stored in Vanguard against the methods that are marked with the (vanguard.public) option.
+// It always evaluates to true, the interceptors call the handler without retrieving the permissions.
+var Public cel.Program = publicProgram{}
+
+type publicProgram struct{}
+
+func (publicProgram) Eval(interface{}) (ref.Val, *cel.EvalDetails, error) {
+	return types.True, nil, nil
+}
+
 func compile(
 	s protoreflect.ServiceDescriptor,
 	m protoreflect.MethodDescriptor,
 	gds []*exprpb.Decl,
+	macros []parser.Macro,
 	funcs ...cel.ProgramOption,
 ) (cel.Program, error) {
+	exp := proto.GetExtension(m.Options(), pb.E_Assert).(string)
+	each := proto.GetExtension(m.Options(), pb.E_AssertEach).(string)
+	if proto.GetExtension(m.Options(), pb.E_Public).(bool) {
+		if exp != "" || each != "" {
+			return nil, fmt.Errorf("vanguard: public method: %s, cannot have an assert", m.FullName())
+		}
+		return Public, nil
+	}
+
 	if m.IsStreamingClient() {
-		return nil, errSkip
+		if exp != "" {
+			return nil, fmt.Errorf("vanguard: (vanguard.assert) is not supported on client streaming method: %s, use (vanguard.assert_each)", m.FullName())
+		}
+		exp = each
+	} else if each != "" {
+		return nil, fmt.Errorf("vanguard: (vanguard.assert_each) is only supported on client streaming methods, method: %s", m.FullName())
 	}
 
-	exp := proto.GetExtension(m.Options(), pb.E_Assert).(string)
+	exp = and(
+		proto.GetExtension(s.ParentFile().Options(), pb.E_FileAssert).(string),
+		proto.GetExtension(s.Options(), pb.E_ServiceAssert).(string),
+		exp,
+	)
 	if exp == "" {
 		return nil, errSkip
 	}
@@ -174,6 +300,7 @@ func compile(
 	env, err := cel.NewEnv(
 		cel.Types(
 			(*pb.Permission)(nil),
+			(*pb.Subject)(nil),
 		),
 		cel.Types(
 			rt.New().Interface(),
@@ -181,6 +308,9 @@ func compile(
 		cel.Declarations(
 			gds...,
 		),
+		cel.Macros(
+			macros...,
+		),
 		cel.Declarations(
 			decls.NewVar(
 				"r",
@@ -209,98 +339,452 @@ func compile(
 	return prg, nil
 }
 
+// and combines the non empty expressions using &&
+func and(exps ...string) string {
+	var parts []string
+	for _, exp := range exps {
+		if exp != "" {
+			parts = append(parts, "("+exp+")")
+		}
+	}
+
+	return strings.Join(parts, " && ")
+}
+
 type matchFuncs struct {
 	rm ResourceMatcher
 	lm LevelMatcher
+
+	// levels holds the names of the levels against their values
+	levels map[int64]string
+
+	conds *conditions
 }
 
 func (mf matchFuncs) any(values ...ref.Val) ref.Val {
-	permissions, pl, rr, err := extractTypes(values)
+	permissions, levels, rr, err := extractTypes(values)
+	if err != nil {
+		return err
+	}
+
+	now := timeOf(values[0])
+	for _, cr := range rr {
+		perm, err := mf.grant(values[0], permissions, levels, cr.Value().(string), now)
+		if err != nil {
+			return types.NewErr(err.Error())
+		} else if perm != nil {
+			mf.match(values[0], perm)
+			return types.True
+		}
+	}
+
+	mf.deny(values[0], levels, rr)
+	return types.False
+}
+
+func (mf matchFuncs) all(values ...ref.Val) ref.Val {
+	permissions, levels, rr, err := extractTypes(values)
 	if err != nil {
 		return err
 	}
 
+	now := timeOf(values[0])
+	for _, cr := range rr {
+		perm, err := mf.grant(values[0], permissions, levels, cr.Value().(string), now)
+		if err != nil {
+			return types.NewErr(err.Error())
+		} else if perm == nil {
+			mf.deny(values[0], levels, []ref.Val{cr})
+			return types.False
+		}
+
+		mf.match(values[0], perm)
+	}
+
+	return types.True
+}
+
+// grant returns the permission that grants one of the levels on resource at now, the levels are preferred in order.
+// It is nil if there is no such permission, or if a deny permission overrides all of them.
+// Allow permissions that do not apply to the call, as per their methods and condition, are skipped.
+//
+// The permissions are walked once, recording for every level the first allow permission that grants it
+// and whether a deny permission applies to it, the same as denied.
+func (mf matchFuncs) grant(u ref.Val, permissions []*pb.Permission, levels []int64, resource string, now time.Time) (*pb.Permission, error) {
+	var (
+		granted = make([]*pb.Permission, len(levels))
+		denied  = make([]bool, len(levels))
+		// affected are the indexes of the levels that the current permission can change the outcome of
+		affected = make([]int, 0, len(levels))
+	)
 	for _, perm := range permissions {
-		if perm == nil {
+		if perm == nil || !validAt(perm, now) {
 			continue
 		}
 
-		if !mf.lm.MatchLevel(perm.Level, pl) {
+		affected = affected[:0]
+		for i, level := range levels {
+			if denied[i] {
+				continue
+			}
+
+			if perm.Effect == pb.Effect_DENY {
+				if dl := levelOf(perm); dl == 0 || mf.lm.MatchLevel(level, dl) {
+					affected = append(affected, i)
+				}
+			} else if perm.Effect == pb.Effect_ALLOW && granted[i] == nil && mf.lm.MatchLevel(levelOf(perm), level) {
+				affected = append(affected, i)
+			}
+		}
+		if len(affected) == 0 {
 			continue
 		}
 
-		for _, pr := range perm.Resources {
-			for _, cr := range rr {
-				ok, err := mf.rm.MatchResource(pr, cr.Value().(string))
-				if err != nil {
-					return types.NewErr(err.Error())
-				} else if ok {
-					return types.True
-				}
+		ok, err := mf.matchResource(perm, resource)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		applies, err := mf.applies(u, perm)
+		for _, i := range affected {
+			if perm.Effect == pb.Effect_DENY && (applies || err != nil) {
+				denied[i] = true
+			} else if perm.Effect == pb.Effect_ALLOW && applies && err == nil {
+				granted[i] = perm
 			}
 		}
 	}
 
-	return types.False
+	for i := range levels {
+		if !denied[i] && granted[i] != nil {
+			return granted[i], nil
+		}
+	}
+
+	return nil, nil
 }
 
-func (mf matchFuncs) all(values ...ref.Val) ref.Val {
-	permissions, pl, rr, err := extractTypes(values)
-	if err != nil {
-		return err
+// denied reports whether a deny permission applies to level on resource at now. A deny permission applies
+// to the levels that match it as the required level, Eg: denying EDITOR also denies OWNER but not VIEWER.
+// A deny permission without a level applies to all the levels.
+// A deny permission whose methods or condition fail to evaluate applies as well.
+func (mf matchFuncs) denied(u ref.Val, permissions []*pb.Permission, level int64, resource string, now time.Time) (bool, error) {
+	for _, perm := range permissions {
+		if perm == nil || perm.Effect != pb.Effect_DENY || !validAt(perm, now) {
+			continue
+		}
+
+		if dl := levelOf(perm); dl != 0 && !mf.lm.MatchLevel(level, dl) {
+			continue
+		}
+
+		ok, err := mf.matchResource(perm, resource)
+		if err != nil {
+			return false, err
+		} else if !ok {
+			continue
+		}
+
+		if held, err := mf.applies(u, perm); err != nil || held {
+			return true, nil
+		}
 	}
 
-	for _, cr := range rr {
-		found := false
-	outer:
-		for _, perm := range permissions {
-			if perm == nil {
+	return false, nil
+}
+
+// applies reports whether perm applies to the call carried by u, as per its methods and condition.
+// A non nil error means that either could not be evaluated, callers must treat it as a denial.
+func (mf matchFuncs) applies(u ref.Val, perm *pb.Permission) (bool, error) {
+	if ok, err := mf.matchMethod(u, perm); err != nil || !ok {
+		return ok, err
+	}
+
+	return mf.holds(u, perm)
+}
+
+// matchMethod reports whether any of the method patterns (glob) of perm match the method of the call carried by u.
+// Permissions without methods match all the methods.
+func (mf matchFuncs) matchMethod(u ref.Val, perm *pb.Permission) (bool, error) {
+	if len(perm.Methods) == 0 {
+		return true, nil
+	}
+
+	pl, ok := u.(*permissionList)
+	if !ok || pl.vars == nil {
+		return false, errNoActivation
+	}
+
+	for _, p := range perm.Methods {
+		ok, err := glob.Match(p, pl.vars.Method)
+		if err != nil {
+			err = fmt.Errorf("vanguard: invalid method pattern: %q, err: %w", p, err)
+			pl.state.fail(err)
+			return false, err
+		} else if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchResource reports whether any of the resources of perm match resource
+func (mf matchFuncs) matchResource(perm *pb.Permission, resource string) (bool, error) {
+	for _, pr := range perm.Resources {
+		if ok, err := mf.rm.MatchResource(pr, resource); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// levelOn returns the best level the user has on the resource, a level is better than another if it matches
+// the other as the required level. Levels that are denied on the resource are ignored.
+//
+// It is an error if the user does not have any permission on the resource, as no level can be safely compared
+// with all the level matchers. The error is recorded in the evaluation state, so that the interceptors deny the call
+// if it is not handled by the assert.
+func (mf matchFuncs) levelOn(u, resource ref.Val) ref.Val {
+	permissions, ok := u.Value().([]*pb.Permission)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(u)
+	}
+
+	cr, ok := resource.Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(resource)
+	}
+
+	var (
+		best  int64
+		found bool
+		now   = timeOf(u)
+	)
+	for _, perm := range permissions {
+		if perm == nil || perm.Effect != pb.Effect_ALLOW || !validAt(perm, now) {
+			continue
+		}
+
+		level := levelOf(perm)
+		if found && (!mf.lm.MatchLevel(level, best) || mf.lm.MatchLevel(best, level)) {
+			continue
+		}
+
+		ok, err := mf.matchResource(perm, cr)
+		if err != nil {
+			return types.NewErr(err.Error())
+		} else if !ok {
+			continue
+		}
+
+		if held, err := mf.applies(u, perm); err != nil || !held {
+			continue
+		}
+
+		denied, err := mf.denied(u, permissions, level, cr, now)
+		if err != nil {
+			return types.NewErr(err.Error())
+		} else if !denied {
+			best, found = level, true
+		}
+	}
+
+	if !found {
+		if state := stateOf(u); state != nil {
+			state.noLevel = true
+			state.deny(0, "", []string{cr})
+		}
+		return types.NewErr("vanguard: no permission on resource: %s", cr)
+	}
+
+	return types.Int(best)
+}
+
+// resourcesWith returns the resource patterns of the allow permissions that match the level and apply to the call.
+// Patterns that are covered by a deny permission that applies to the level are dropped, a pattern is covered if
+// it is the same as the pattern of the deny permission or if it matches it as a resource, Eg: denying parents/1/**
+// drops both parents/1/** and parents/1/examples/*. Patterns that are only partly denied are returned as is.
+func (mf matchFuncs) resourcesWith(u, level ref.Val) ref.Val {
+	permissions, ok := u.Value().([]*pb.Permission)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(u)
+	}
+
+	pl, ok := level.Value().(int64)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(level)
+	}
+
+	var (
+		rr   = []string{}
+		seen = map[string]bool{}
+		now  = timeOf(u)
+	)
+	for _, perm := range permissions {
+		if perm == nil || perm.Effect != pb.Effect_ALLOW || !validAt(perm, now) || !mf.lm.MatchLevel(levelOf(perm), pl) {
+			continue
+		}
+
+		if held, err := mf.applies(u, perm); err != nil || !held {
+			continue
+		}
+
+		for _, pr := range perm.Resources {
+			if seen[pr] {
 				continue
 			}
+			seen[pr] = true
 
-			if !mf.lm.MatchLevel(perm.Level, pl) {
+			if denied, err := mf.covered(u, permissions, pl, pr, now); err != nil || denied {
 				continue
 			}
+			rr = append(rr, pr)
+		}
+	}
+
+	return types.NewStringList(types.DefaultTypeAdapter, rr)
+}
+
+// covered reports whether a deny permission that applies to level covers the resource pattern, see resourcesWith
+func (mf matchFuncs) covered(u ref.Val, permissions []*pb.Permission, level int64, pattern string, now time.Time) (bool, error) {
+	for _, perm := range permissions {
+		if perm == nil || perm.Effect != pb.Effect_DENY || !validAt(perm, now) {
+			continue
+		}
+
+		if dl := levelOf(perm); dl != 0 && !mf.lm.MatchLevel(level, dl) {
+			continue
+		}
 
-			for _, r := range perm.Resources {
-				ok, err := mf.rm.MatchResource(r, cr.Value().(string))
-				if err != nil {
-					return types.NewErr(err.Error())
-				} else if ok {
-					found = true
-					break outer
+		for _, dr := range perm.Resources {
+			if dr == pattern {
+				if held, err := mf.applies(u, perm); err != nil || held {
+					return true, nil
 				}
+				break
 			}
 		}
-		if !found {
-			return types.False
+	}
+
+	return mf.denied(u, permissions, level, pattern, now)
+}
+
+// match records the matched permission in the evaluation state carried by u
+func (mf matchFuncs) match(u ref.Val, perm *pb.Permission) {
+	if state := stateOf(u); state != nil {
+		state.match(perm)
+	}
+}
+
+// deny records the failed check in the evaluation state carried by u
+func (mf matchFuncs) deny(u ref.Val, levels []int64, resources []ref.Val) {
+	state := stateOf(u)
+	if state == nil || len(levels) == 0 {
+		return
+	}
+
+	rr := make([]string, 0, len(resources))
+	for _, r := range resources {
+		rr = append(rr, r.Value().(string))
+	}
+
+	names := make([]string, 0, len(levels))
+	for _, l := range levels {
+		if n, ok := mf.levels[l]; ok {
+			names = append(names, n)
 		}
 	}
 
-	return types.True
+	state.deny(levels[0], strings.Join(names, "|"), rr)
+}
+
+// levelOf returns the level of perm, the role is used if the level is not set
+func levelOf(perm *pb.Permission) int64 {
+	if perm.Level == 0 {
+		return int64(perm.Role)
+	}
+
+	return perm.Level
 }
 
-func extractTypes(values []ref.Val) ([]*pb.Permission, int64, []ref.Val, ref.Val) {
+// validAt reports whether perm is valid at t, as per its not_before and not_after
+func validAt(perm *pb.Permission, t time.Time) bool {
+	if nb := perm.GetNotBefore(); nb != nil && t.Before(nb.AsTime()) {
+		return false
+	}
+
+	if na := perm.GetNotAfter(); na != nil && !t.Before(na.AsTime()) {
+		return false
+	}
+
+	return true
+}
+
+// extractTypes returns the permissions, the levels and the resources of a hasAny or hasAll call.
+// The level can either be a single level or a list of levels.
+func extractTypes(values []ref.Val) ([]*pb.Permission, []int64, []ref.Val, ref.Val) {
 	if len(values) != 3 {
-		return nil, -1, nil, types.NoSuchOverloadErr()
+		return nil, nil, nil, types.NoSuchOverloadErr()
 	}
 
 	u, ok := values[0].Value().([]*pb.Permission)
 	if !ok {
-		return nil, -1, nil, types.MaybeNoSuchOverloadErr(values[0])
+		return nil, nil, nil, types.MaybeNoSuchOverloadErr(values[0])
 	}
 
-	lv, ok := values[1].Value().(int64)
+	var levels []int64
+	if lv, ok := values[1].Value().(int64); ok {
+		levels = []int64{lv}
+	} else {
+		lvs, err := listOf(values[1])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		levels = make([]int64, 0, len(lvs))
+		for _, v := range lvs {
+			lv, ok := v.Value().(int64)
+			if !ok {
+				return nil, nil, nil, types.MaybeNoSuchOverloadErr(v)
+			}
+			levels = append(levels, lv)
+		}
+	}
+
+	vv, err := listOf(values[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return u, levels, vv, nil
+}
+
+// listOf returns the elements of a cel list
+func listOf(v ref.Val) ([]ref.Val, ref.Val) {
+	if vv, ok := v.Value().([]ref.Val); ok {
+		return vv, nil
+	}
+
+	l, ok := v.(traits.Lister)
 	if !ok {
-		return nil, -1, nil, types.MaybeNoSuchOverloadErr(values[1])
+		return nil, types.MaybeNoSuchOverloadErr(v)
 	}
 
-	vv, ok := values[2].Value().([]ref.Val)
+	size, ok := l.Size().(types.Int)
 	if !ok {
-		return nil, -1, nil, types.MaybeNoSuchOverloadErr(values[2])
+		return nil, types.MaybeNoSuchOverloadErr(v)
+	}
+
+	vv := make([]ref.Val, 0, size)
+	for i := types.Int(0); i < size; i++ {
+		vv = append(vv, l.Get(i))
 	}
 
-	return u, lv, vv, nil
+	return vv, nil
 }
 
 type MultiError []error
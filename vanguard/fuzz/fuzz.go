@@ -2,6 +2,8 @@ package fuzz
 
 var ff = [...]interface{}{
 	FuzzPermission,
+	FuzzSubject,
+	FuzzMacro,
 }
 
 func FuzzFuncs() []interface{} {
@@ -11,4 +11,22 @@ import (
 func FuzzPermission(msg *pb.Permission, c fuzz.Continue) {
     c.Fuzz(&msg.Level)
     c.Fuzz(&msg.Resources)
+    c.Fuzz(&msg.NotBefore)
+    c.Fuzz(&msg.NotAfter)
+    c.Fuzz(&msg.Role)
+    c.Fuzz(&msg.Effect)
+    c.Fuzz(&msg.Condition)
+    c.Fuzz(&msg.Methods)
+}
+
+func FuzzSubject(msg *pb.Subject, c fuzz.Continue) {
+    c.Fuzz(&msg.Id)
+    c.Fuzz(&msg.Attributes)
+    c.Fuzz(&msg.Groups)
+}
+
+func FuzzMacro(msg *pb.Macro, c fuzz.Continue) {
+    c.Fuzz(&msg.Name)
+    c.Fuzz(&msg.Params)
+    c.Fuzz(&msg.Expr)
 }
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +22,177 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Effect int32
+
+const (
+	Effect_ALLOW Effect = 0
+	Effect_DENY  Effect = 1
+)
+
+// Enum value maps for Effect.
+var (
+	Effect_name = map[int32]string{
+		0: "ALLOW",
+		1: "DENY",
+	}
+	Effect_value = map[string]int32{
+		"ALLOW": 0,
+		"DENY":  1,
+	}
+)
+
+func (x Effect) Enum() *Effect {
+	p := new(Effect)
+	*p = x
+	return p
+}
+
+func (x Effect) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Effect) Descriptor() protoreflect.EnumDescriptor {
+	return file_vanguard_vanguard_proto_enumTypes[0].Descriptor()
+}
+
+func (Effect) Type() protoreflect.EnumType {
+	return &file_vanguard_vanguard_proto_enumTypes[0]
+}
+
+func (x Effect) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Effect.Descriptor instead.
+func (Effect) EnumDescriptor() ([]byte, []int) {
+	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{0}
+}
+
+// Level has the default levels, the same as vanguard.DefaultLevels.
+type Level int32
+
+const (
+	Level_LEVEL_UNSPECIFIED Level = 0
+	Level_OWNER             Level = 1
+	Level_MANAGER           Level = 5
+	Level_EDITOR            Level = 10
+	Level_VIEWER            Level = 15
+)
+
+// Enum value maps for Level.
+var (
+	Level_name = map[int32]string{
+		0:  "LEVEL_UNSPECIFIED",
+		1:  "OWNER",
+		5:  "MANAGER",
+		10: "EDITOR",
+		15: "VIEWER",
+	}
+	Level_value = map[string]int32{
+		"LEVEL_UNSPECIFIED": 0,
+		"OWNER":             1,
+		"MANAGER":           5,
+		"EDITOR":            10,
+		"VIEWER":            15,
+	}
+)
+
+func (x Level) Enum() *Level {
+	p := new(Level)
+	*p = x
+	return p
+}
+
+func (x Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_vanguard_vanguard_proto_enumTypes[1].Descriptor()
+}
+
+func (Level) Type() protoreflect.EnumType {
+	return &file_vanguard_vanguard_proto_enumTypes[1]
+}
+
+func (x Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Level.Descriptor instead.
+func (Level) EnumDescriptor() ([]byte, []int) {
+	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{1}
+}
+
+// Macro is a named, parameterised policy fragment. Calls to it are expanded at
+// compile time by substituting the params in expr with the arguments of the
+// call, the result is type checked against each method that uses it. Macros
+// cannot call other macros.
+//
+// Eg: { name: "canEditIn" params: "parent" expr: "u.hasAny(EDITOR, [parent])" }
+type Macro struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Params []string `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	Expr   string   `protobuf:"bytes,3,opt,name=expr,proto3" json:"expr,omitempty"`
+}
+
+func (x *Macro) Reset() {
+	*x = Macro{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vanguard_vanguard_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Macro) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Macro) ProtoMessage() {}
+
+func (x *Macro) ProtoReflect() protoreflect.Message {
+	mi := &file_vanguard_vanguard_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Macro.ProtoReflect.Descriptor instead.
+func (*Macro) Descriptor() ([]byte, []int) {
+	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Macro) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Macro) GetParams() []string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Macro) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
 type Permission struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -28,12 +200,34 @@ type Permission struct {
 
 	Level     int64    `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
 	Resources []string `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+	// not_before and not_after bound the time in which the permission is valid,
+	// not_after is exclusive. The permission is ignored by hasAny and hasAll
+	// outside of it. Either of them can be omitted.
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// role is an enum typed alternative to level, it is used if level is not
+	// set. Enums are open in proto3, so the values of the enum passed to
+	// WithRolesFromEnum can be stored as is.
+	Role Level `protobuf:"varint,5,opt,name=role,proto3,enum=vanguard.Level" json:"role,omitempty"`
+	// effect of the permission, deny permissions override the allow permissions
+	// in hasAny and hasAll.
+	Effect Effect `protobuf:"varint,6,opt,name=effect,proto3,enum=vanguard.Effect" json:"effect,omitempty"`
+	// condition is a cel expression that must hold for the permission to apply.
+	// It is compiled against the request of the method being called, and can
+	// use the same variables as the asserts except for `u`. A condition that
+	// fails to compile or evaluate denies an allow permission and applies a
+	// deny permission.
+	Condition string `protobuf:"bytes,7,opt,name=condition,proto3" json:"condition,omitempty"`
+	// methods restrict the permission to the calls whose full method name
+	// matches one of the patterns (glob), Eg: /example.ExampleService/Get*.
+	// The permission applies to all the methods if it is empty.
+	Methods []string `protobuf:"bytes,8,rep,name=methods,proto3" json:"methods,omitempty"`
 }
 
 func (x *Permission) Reset() {
 	*x = Permission{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vanguard_vanguard_proto_msgTypes[0]
+		mi := &file_vanguard_vanguard_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +240,7 @@ func (x *Permission) String() string {
 func (*Permission) ProtoMessage() {}
 
 func (x *Permission) ProtoReflect() protoreflect.Message {
-	mi := &file_vanguard_vanguard_proto_msgTypes[0]
+	mi := &file_vanguard_vanguard_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +253,7 @@ func (x *Permission) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Permission.ProtoReflect.Descriptor instead.
 func (*Permission) Descriptor() ([]byte, []int) {
-	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{0}
+	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Permission) GetLevel() int64 {
@@ -76,6 +270,115 @@ func (x *Permission) GetResources() []string {
 	return nil
 }
 
+func (x *Permission) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *Permission) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *Permission) GetRole() Level {
+	if x != nil {
+		return x.Role
+	}
+	return Level_LEVEL_UNSPECIFIED
+}
+
+func (x *Permission) GetEffect() Effect {
+	if x != nil {
+		return x.Effect
+	}
+	return Effect_ALLOW
+}
+
+func (x *Permission) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *Permission) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+// Subject is the caller of an rpc, it is available to the asserts as `s`.
+type Subject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id uniquely identifies the caller, Eg: the user id
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// attributes of the caller, Eg: email, tenant
+	Attributes map[string]string `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// groups the caller belongs to
+	Groups []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *Subject) Reset() {
+	*x = Subject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vanguard_vanguard_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subject) ProtoMessage() {}
+
+func (x *Subject) ProtoReflect() protoreflect.Message {
+	mi := &file_vanguard_vanguard_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subject.ProtoReflect.Descriptor instead.
+func (*Subject) Descriptor() ([]byte, []int) {
+	return file_vanguard_vanguard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Subject) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subject) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Subject) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
 var file_vanguard_vanguard_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
@@ -85,12 +388,86 @@ var file_vanguard_vanguard_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,2862693,opt,name=assert",
 		Filename:      "vanguard/vanguard.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         2862694,
+		Name:          "vanguard.assert_each",
+		Tag:           "bytes,2862694,opt,name=assert_each",
+		Filename:      "vanguard/vanguard.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         2862695,
+		Name:          "vanguard.public",
+		Tag:           "varint,2862695,opt,name=public",
+		Filename:      "vanguard/vanguard.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         2862693,
+		Name:          "vanguard.service_assert",
+		Tag:           "bytes,2862693,opt,name=service_assert",
+		Filename:      "vanguard/vanguard.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         2862693,
+		Name:          "vanguard.file_assert",
+		Tag:           "bytes,2862693,opt,name=file_assert",
+		Filename:      "vanguard/vanguard.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: ([]*Macro)(nil),
+		Field:         2862694,
+		Name:          "vanguard.macro",
+		Tag:           "bytes,2862694,rep,name=macro",
+		Filename:      "vanguard/vanguard.proto",
+	},
 }
 
 // Extension fields to descriptorpb.MethodOptions.
 var (
 	// optional string assert = 2862693;
 	E_Assert = &file_vanguard_vanguard_proto_extTypes[0]
+	// assert_each is evaluated against every message received on a client
+	// streaming or bidi streaming rpc.
+	//
+	// optional string assert_each = 2862694;
+	E_AssertEach = &file_vanguard_vanguard_proto_extTypes[1]
+	// public marks a method that can be called without any checks, it is
+	// required for methods without an assert when deny by default is enabled.
+	//
+	// optional bool public = 2862695;
+	E_Public = &file_vanguard_vanguard_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// service_assert is combined with the asserts of all the methods in the
+	// service. It is used as is by the methods that do not have an assert and
+	// is combined using && with the ones that do.
+	//
+	// optional string service_assert = 2862693;
+	E_ServiceAssert = &file_vanguard_vanguard_proto_extTypes[3]
+)
+
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// file_assert is combined with the asserts of all the methods of all the
+	// services in the file, the same way as the service_assert.
+	//
+	// optional string file_assert = 2862693;
+	E_FileAssert = &file_vanguard_vanguard_proto_extTypes[4]
+	// macro declares a named policy fragment that can be called like a function
+	// by the asserts of all the files in the same package.
+	//
+	// repeated vanguard.Macro macro = 2862694;
+	E_Macro = &file_vanguard_vanguard_proto_extTypes[5]
 )
 
 var File_vanguard_vanguard_proto protoreflect.FileDescriptor
@@ -100,18 +477,80 @@ var file_vanguard_vanguard_proto_rawDesc = []byte{
 	0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x76, 0x61, 0x6e, 0x67, 0x75,
 	0x61, 0x72, 0x64, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x40, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x3a, 0x39, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x72,
-	0x74, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0xe5, 0xdc, 0xae, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65,
-	0x72, 0x74, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x73, 0x72, 0x69, 0x6b, 0x72, 0x73, 0x6e, 0x61, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61,
-	0x72, 0x64, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x3b, 0x76, 0x61, 0x6e, 0x67,
-	0x75, 0x61, 0x72, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x47, 0x0a, 0x05, 0x4d, 0x61, 0x63, 0x72, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x65,
+	0x78, 0x70, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72, 0x22,
+	0xbb, 0x02, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x37, 0x0a,
+	0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6e, 0x6f,
+	0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2e,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x76, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2e, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x06, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x22, 0xb3, 0x01,
+	0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x41, 0x0a, 0x0a, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x2a, 0x1d, 0x0a, 0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x09, 0x0a,
+	0x05, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x45, 0x4e, 0x59,
+	0x10, 0x01, 0x2a, 0x4e, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x11, 0x4c,
+	0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x44,
+	0x49, 0x54, 0x4f, 0x52, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52,
+	0x10, 0x0f, 0x3a, 0x39, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x12, 0x1e, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0xdc, 0xae,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x3a, 0x42, 0x0a,
+	0x0b, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x65, 0x61, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe6, 0xdc, 0xae,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x45, 0x61, 0x63,
+	0x68, 0x3a, 0x39, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x1e, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe7, 0xdc, 0xae, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x3a, 0x49, 0x0a, 0x0e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x12, 0x1f,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0xe5, 0xdc, 0xae, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x3a, 0x40, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0xdc, 0xae, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66,
+	0x69, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x3a, 0x46, 0x0a, 0x05, 0x6d, 0x61, 0x63,
+	0x72, 0x6f, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xe6, 0xdc, 0xae, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x72, 0x64, 0x2e, 0x4d, 0x61, 0x63, 0x72, 0x6f, 0x52, 0x05, 0x6d, 0x61, 0x63, 0x72,
+	0x6f, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x72, 0x69, 0x6b, 0x72, 0x73, 0x6e, 0x61, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72,
+	0x64, 0x2f, 0x76, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x72, 0x64, 0x3b, 0x76, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x72, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -126,18 +565,38 @@ func file_vanguard_vanguard_proto_rawDescGZIP() []byte {
 	return file_vanguard_vanguard_proto_rawDescData
 }
 
-var file_vanguard_vanguard_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_vanguard_vanguard_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_vanguard_vanguard_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_vanguard_vanguard_proto_goTypes = []interface{}{
-	(*Permission)(nil),                 // 0: vanguard.Permission
-	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+	(Effect)(0),                         // 0: vanguard.Effect
+	(Level)(0),                          // 1: vanguard.Level
+	(*Macro)(nil),                       // 2: vanguard.Macro
+	(*Permission)(nil),                  // 3: vanguard.Permission
+	(*Subject)(nil),                     // 4: vanguard.Subject
+	nil,                                 // 5: vanguard.Subject.AttributesEntry
+	(*timestamppb.Timestamp)(nil),       // 6: google.protobuf.Timestamp
+	(*descriptorpb.MethodOptions)(nil),  // 7: google.protobuf.MethodOptions
+	(*descriptorpb.ServiceOptions)(nil), // 8: google.protobuf.ServiceOptions
+	(*descriptorpb.FileOptions)(nil),    // 9: google.protobuf.FileOptions
 }
 var file_vanguard_vanguard_proto_depIdxs = []int32{
-	1, // 0: vanguard.assert:extendee -> google.protobuf.MethodOptions
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	0, // [0:1] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: vanguard.Permission.not_before:type_name -> google.protobuf.Timestamp
+	6,  // 1: vanguard.Permission.not_after:type_name -> google.protobuf.Timestamp
+	1,  // 2: vanguard.Permission.role:type_name -> vanguard.Level
+	0,  // 3: vanguard.Permission.effect:type_name -> vanguard.Effect
+	5,  // 4: vanguard.Subject.attributes:type_name -> vanguard.Subject.AttributesEntry
+	7,  // 5: vanguard.assert:extendee -> google.protobuf.MethodOptions
+	7,  // 6: vanguard.assert_each:extendee -> google.protobuf.MethodOptions
+	7,  // 7: vanguard.public:extendee -> google.protobuf.MethodOptions
+	8,  // 8: vanguard.service_assert:extendee -> google.protobuf.ServiceOptions
+	9,  // 9: vanguard.file_assert:extendee -> google.protobuf.FileOptions
+	9,  // 10: vanguard.macro:extendee -> google.protobuf.FileOptions
+	2,  // 11: vanguard.macro:type_name -> vanguard.Macro
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	11, // [11:12] is the sub-list for extension type_name
+	5,  // [5:11] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_vanguard_vanguard_proto_init() }
@@ -147,6 +606,18 @@ func file_vanguard_vanguard_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_vanguard_vanguard_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Macro); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vanguard_vanguard_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Permission); i {
 			case 0:
 				return &v.state
@@ -158,19 +629,32 @@ func file_vanguard_vanguard_proto_init() {
 				return nil
 			}
 		}
+		file_vanguard_vanguard_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subject); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_vanguard_vanguard_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
-			NumExtensions: 1,
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_vanguard_vanguard_proto_goTypes,
 		DependencyIndexes: file_vanguard_vanguard_proto_depIdxs,
+		EnumInfos:         file_vanguard_vanguard_proto_enumTypes,
 		MessageInfos:      file_vanguard_vanguard_proto_msgTypes,
 		ExtensionInfos:    file_vanguard_vanguard_proto_extTypes,
 	}.Build()
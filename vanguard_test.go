@@ -1,12 +1,19 @@
 package vanguard_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
 	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestAssertions(t *testing.T) {
@@ -54,3 +61,624 @@ func BenchmarkAssertions(b *testing.B) {
 		})
 	}
 }
+
+func TestDefaultAsserts(t *testing.T) {
+	const (
+		AdminService = "/example.ExampleAdminService"
+		Purge        = AdminService + "/PurgeExamples"
+		Restore      = AdminService + "/RestoreExample"
+		ListAdmin    = AdminService + "/ListAdminExamples"
+	)
+
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	testcases := []struct {
+		Name        string
+		Method      string
+		Request     proto.Message
+		Permissions []*pb.Permission
+		Allow       bool
+	}{
+		{
+			Name:        "Inherit",
+			Method:      Purge,
+			Request:     &expb.ListExamplesRequest{},
+			Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/admin"}}},
+			Allow:       true,
+		},
+		{
+			Name:        "InheritService",
+			Method:      Purge,
+			Request:     &expb.ListExamplesRequest{},
+			Permissions: []*pb.Permission{{Level: Manager, Resources: []string{"/admin"}}},
+			Allow:       false,
+		},
+		{
+			Name:    "Combined",
+			Method:  Restore,
+			Request: &expb.GetExampleRequest{Name: "/parents/1/examples/1"},
+			Permissions: []*pb.Permission{
+				{Level: Owner, Resources: []string{"/admin"}},
+				{Level: Owner, Resources: []string{"/parents/1/**"}},
+			},
+			Allow: true,
+		},
+		{
+			Name:        "CombinedMethod",
+			Method:      Restore,
+			Request:     &expb.GetExampleRequest{Name: "/parents/1/examples/1"},
+			Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/admin"}}},
+			Allow:       false,
+		},
+		{
+			Name:        "CombinedService",
+			Method:      Restore,
+			Request:     &expb.GetExampleRequest{Name: "/parents/1/examples/1"},
+			Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/parents/1/**"}}},
+			Allow:       false,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			res, _, err := store[tc.Method].Eval(map[string]interface{}{
+				"r": tc.Request,
+				"u": tc.Permissions,
+			})
+			if err != nil {
+				t.Fatalf("unable to evaluate expr: %v", err)
+			}
+
+			if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+				t.Fatalf("output mismatch, exp: %v, act: %v", tc.Allow, res.Value())
+			}
+		})
+	}
+
+	if store[ListAdmin] != vanguard.Public {
+		t.Fatalf("public method should not inherit the default asserts")
+	}
+}
+
+func TestMacros(t *testing.T) {
+	ms, err := vanguard.NewMacros([]*pb.Macro{
+		{Name: "twice", Params: []string{"p"}, Expr: "p + p"},
+		{Name: "positive", Params: []string{"p"}, Expr: "p.all(p, p > 0)"},
+		{Name: "between", Params: []string{"v", "lo", "hi"}, Expr: "v >= lo && v < hi"},
+	})
+	if err != nil {
+		t.Fatalf("unable to create macros: %v", err)
+	}
+
+	env, err := cel.NewEnv(cel.Macros(ms...), cel.Declarations(decls.NewVar("x", decls.Int)))
+	if err != nil {
+		t.Fatalf("unable to create env: %v", err)
+	}
+
+	for _, exp := range []string{
+		"twice(x + 1) == 6",
+		"positive([x, 1]) && !positive([x, -1])",
+		"between(twice(x), 4, 5)",
+	} {
+		ast, iss := env.Compile(exp)
+		if err := iss.Err(); err != nil {
+			t.Fatalf("unable to compile %q: %v", exp, err)
+		}
+
+		prg, err := env.Program(ast)
+		if err != nil {
+			t.Fatalf("unable to create program for %q: %v", exp, err)
+		}
+
+		res, _, err := prg.Eval(map[string]interface{}{"x": 2})
+		if err != nil {
+			t.Fatalf("unable to evaluate %q: %v", exp, err)
+		}
+
+		if res != types.True {
+			t.Fatalf("expected %q to be true, got: %v", exp, res)
+		}
+	}
+
+	if _, iss := env.Compile("twice('a') == 1"); iss.Err() == nil {
+		t.Fatalf("expected expansions to be type checked")
+	}
+
+	invalid := [][]*pb.Macro{
+		{{Params: []string{"p"}, Expr: "p"}},
+		{{Name: "m", Expr: "true"}, {Name: "m", Expr: "false"}},
+		{{Name: "m", Params: []string{"p", "p"}, Expr: "p"}},
+		{{Name: "m", Expr: "u.hasAny("}},
+	}
+	for _, ms := range invalid {
+		if _, err := vanguard.NewMacros(ms); err == nil {
+			t.Fatalf("expected an error for macros: %v", ms)
+		}
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	const Transfer = Service + "/TransferExamples"
+
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	publish := &expb.UpdateExampleRequest{Example: &expb.Example{Name: "/parents/1/examples/1", Visibility: expb.Visibility_PUBLIC}}
+	hide := &expb.UpdateExampleRequest{Example: &expb.Example{Name: "/parents/1/examples/1", Visibility: expb.Visibility_PRIVATE}}
+
+	testcases := []struct {
+		Name        string
+		Method      string
+		Request     proto.Message
+		Permissions []*pb.Permission
+		Allow       bool
+	}{
+		{
+			Name:        "EditorPrivate",
+			Method:      Update,
+			Request:     hide,
+			Permissions: []*pb.Permission{{Level: Editor, Resources: []string{"/parents/1/**"}}},
+			Allow:       true,
+		},
+		{
+			Name:        "EditorPublic",
+			Method:      Update,
+			Request:     publish,
+			Permissions: []*pb.Permission{{Level: Editor, Resources: []string{"/parents/1/**"}}},
+			Allow:       false,
+		},
+		{
+			Name:    "BestLevel",
+			Method:  Update,
+			Request: publish,
+			Permissions: []*pb.Permission{
+				{Level: Editor, Resources: []string{"/parents/1/**"}},
+				{Level: Owner, Resources: []string{"/parents/1/examples/*"}},
+				{Level: Viewer, Resources: []string{"/parents/**"}},
+			},
+			Allow: true,
+		},
+		{
+			Name:    "OwnerElsewhere",
+			Method:  Update,
+			Request: publish,
+			Permissions: []*pb.Permission{
+				{Level: Editor, Resources: []string{"/parents/1/**"}},
+				{Level: Owner, Resources: []string{"/parents/2/**"}},
+			},
+			Allow: false,
+		},
+		{
+			Name:        "ResourcesWith",
+			Method:      Transfer,
+			Request:     &expb.ListExamplesRequest{Parent: "/parents/1"},
+			Permissions: []*pb.Permission{{Level: Owner, Resources: []string{"/parents/2/**", "/parents/1/**"}}},
+			Allow:       true,
+		},
+		{
+			Name:        "ResourcesWithLevel",
+			Method:      Transfer,
+			Request:     &expb.ListExamplesRequest{Parent: "/parents/1"},
+			Permissions: []*pb.Permission{{Level: Editor, Resources: []string{"/parents/1/**"}}},
+			Allow:       false,
+		}, {
+			Name:    "ResourcesWithDenied",
+			Method:  Transfer,
+			Request: &expb.ListExamplesRequest{Parent: "/parents/1"},
+			Permissions: []*pb.Permission{
+				{Level: Owner, Resources: []string{"/parents/1/**"}},
+				{Level: Owner, Resources: []string{"/parents/1/**"}, Effect: pb.Effect_DENY},
+			},
+			Allow: false,
+		},
+		{
+			Name:    "ResourcesWithDeniedAbove",
+			Method:  Transfer,
+			Request: &expb.ListExamplesRequest{Parent: "/parents/1"},
+			Permissions: []*pb.Permission{
+				{Level: Owner, Resources: []string{"/parents/1/**"}},
+				{Resources: []string{"/parents/**"}, Effect: pb.Effect_DENY},
+			},
+			Allow: false,
+		},
+		{
+			Name:    "ResourcesWithDeniedElsewhere",
+			Method:  Transfer,
+			Request: &expb.ListExamplesRequest{Parent: "/parents/1"},
+			Permissions: []*pb.Permission{
+				{Level: Owner, Resources: []string{"/parents/1/**"}},
+				{Level: Owner, Resources: []string{"/parents/2/**"}, Effect: pb.Effect_DENY},
+			},
+			Allow: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			res, _, err := store[tc.Method].Eval(map[string]interface{}{
+				"r": tc.Request,
+				"u": tc.Permissions,
+			})
+			if err != nil {
+				t.Fatalf("unable to evaluate expr: %v", err)
+			}
+
+			if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+				t.Fatalf("output mismatch, exp: %v, act: %v", tc.Allow, res.Value())
+			}
+		})
+	}
+}
+
+func TestResource(t *testing.T) {
+	const GetRevision = Service + "/GetExampleRevision"
+
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	perms := []*pb.Permission{{Level: Viewer, Resources: []string{"/parents/1/examples/1/**", "/parents/José/examples/1/**", "/parents/a b/examples/1/**"}}}
+
+	testcases := []struct {
+		Name    string
+		Request *expb.GetExampleRevisionRequest
+		Allow   bool
+		Err     bool
+	}{
+		{Name: "Allowed", Request: &expb.GetExampleRevisionRequest{Parent: "1", Example: "1", Revision: "3"}, Allow: true},
+		{Name: "Denied", Request: &expb.GetExampleRevisionRequest{Parent: "1", Example: "2", Revision: "3"}, Allow: false},
+		{Name: "Separator", Request: &expb.GetExampleRevisionRequest{Parent: "1/examples/1", Example: "2", Revision: "3"}, Allow: false},
+		{Name: "Wildcard", Request: &expb.GetExampleRevisionRequest{Parent: "1", Example: "1/**", Revision: "3"}, Allow: false},
+		{Name: "Unicode", Request: &expb.GetExampleRevisionRequest{Parent: "José", Example: "1", Revision: "3"}, Allow: true},
+		{Name: "Space", Request: &expb.GetExampleRevisionRequest{Parent: "a b", Example: "1", Revision: "3"}, Allow: true},
+		{Name: "DotSegment", Request: &expb.GetExampleRevisionRequest{Parent: "1", Example: "..", Revision: "3"}, Err: true},
+		{Name: "Empty", Request: &expb.GetExampleRevisionRequest{Parent: "1", Revision: "3"}, Err: true},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			res, _, err := store[GetRevision].Eval(map[string]interface{}{
+				"r": tc.Request,
+				"u": perms,
+			})
+			if tc.Err {
+				if err == nil {
+					t.Fatalf("expected an error, got: %v", res)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unable to evaluate expr: %v", err)
+			}
+
+			if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+				t.Fatalf("output mismatch, exp: %v, act: %v", tc.Allow, res.Value())
+			}
+		})
+	}
+
+	for value, exp := range map[string]string{
+		"a/b*[c]?": "a%2Fb%2A%5Bc%5D%3F",
+		`a\b%2F`:   "a%5Cb%252F",
+		"José":     "José",
+		"a b":      "a b",
+		"...":      "...",
+	} {
+		if v, err := vanguard.DefaultEscapeResource(value); err != nil || v != exp {
+			t.Fatalf("unexpected escaped value for %q: %q, err: %v", value, v, err)
+		}
+	}
+}
+
+func TestLevelList(t *testing.T) {
+	const Publish = Service + "/PublishExample"
+
+	store, err := vanguard.NewVanguard(vanguard.WithLevelMatcher(&vanguard.ExactLevelMatcher{}))
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	for _, tc := range []struct {
+		Level int64
+		Allow bool
+	}{
+		{Level: Owner, Allow: true},
+		{Level: Manager, Allow: false},
+		{Level: Editor, Allow: true},
+		{Level: Viewer, Allow: false},
+	} {
+		res, _, err := store[Publish].Eval(map[string]interface{}{
+			"r": &expb.GetExampleRequest{Name: "/parents/1/examples/1"},
+			"u": []*pb.Permission{{Level: tc.Level, Resources: []string{"/parents/1/**"}}},
+		})
+		if err != nil {
+			t.Fatalf("unable to evaluate expr: %v", err)
+		}
+
+		if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+			t.Fatalf("output mismatch for level %d, exp: %v, act: %v", tc.Level, tc.Allow, res.Value())
+		}
+	}
+}
+
+func TestRolesFromEnum(t *testing.T) {
+	store, err := vanguard.NewVanguard(vanguard.WithRolesFromEnum(pb.Level(0).Descriptor()))
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	for _, tc := range []struct {
+		Name       string
+		Permission *pb.Permission
+		Allow      bool
+	}{
+		{Name: "Role", Permission: &pb.Permission{Role: pb.Level_EDITOR, Resources: []string{"/parents/1/**"}}, Allow: true},
+		{Name: "RoleBelow", Permission: &pb.Permission{Role: pb.Level_VIEWER, Resources: []string{"/parents/1/**"}}, Allow: false},
+		{Name: "LevelFirst", Permission: &pb.Permission{Level: Viewer, Role: pb.Level_OWNER, Resources: []string{"/parents/1/**"}}, Allow: false},
+	} {
+		res, _, err := store[Create].Eval(map[string]interface{}{
+			"r": &expb.CreateExampleRequest{Parent: "/parents/1"},
+			"u": []*pb.Permission{tc.Permission},
+		})
+		if err != nil {
+			t.Fatalf("unable to evaluate expr: %v", err)
+		}
+
+		if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+			t.Fatalf("%s: output mismatch, exp: %v, act: %v", tc.Name, tc.Allow, res.Value())
+		}
+	}
+}
+
+func TestDenyPermissions(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	perms := []*pb.Permission{
+		{Level: Editor, Resources: []string{"/parents/**"}},
+		{Level: Editor, Resources: []string{"/parents/legal/**"}, Effect: pb.Effect_DENY},
+		{Resources: []string{"/parents/secret/**"}, Effect: pb.Effect_DENY},
+		{Level: Owner, Resources: []string{"/parents/drafts/**"}, Effect: pb.Effect_DENY},
+	}
+
+	testcases := []struct {
+		Name    string
+		Method  string
+		Request proto.Message
+		Allow   bool
+	}{
+		{Name: "Allowed", Method: Create, Request: &expb.CreateExampleRequest{Parent: "/parents/books"}, Allow: true},
+		{Name: "Denied", Method: Create, Request: &expb.CreateExampleRequest{Parent: "/parents/legal"}, Allow: false},
+		{Name: "LowerLevel", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/legal/examples/1"}, Allow: true},
+		{Name: "AllLevels", Method: Get, Request: &expb.GetExampleRequest{Name: "/parents/secret/examples/1"}, Allow: false},
+		{Name: "LevelListOneDenied", Method: Service + "/PublishExample", Request: &expb.GetExampleRequest{Name: "/parents/drafts/examples/1"}, Allow: true},
+		{Name: "LevelListAllDenied", Method: Service + "/PublishExample", Request: &expb.GetExampleRequest{Name: "/parents/legal/examples/1"}, Allow: false},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			res, _, err := store[tc.Method].Eval(map[string]interface{}{
+				"r": tc.Request,
+				"u": perms,
+			})
+			if err != nil {
+				t.Fatalf("unable to evaluate expr: %v", err)
+			}
+
+			if v, ok := res.Value().(bool); !ok || v != tc.Allow {
+				t.Fatalf("output mismatch, exp: %v, act: %v", tc.Allow, res.Value())
+			}
+		})
+	}
+}
+
+func TestRoleRegistry(t *testing.T) {
+	owner := vanguard.Role{
+		Name:   "Book Owner",
+		Params: []string{"book"},
+		Permissions: []vanguard.PermissionTemplate{
+			{Level: Owner, Resources: []string{"/books/{book}", "/books/{book}/**"}},
+		},
+	}
+	reader := vanguard.Role{
+		Name:   "Page Reader",
+		Params: []string{"book", "page"},
+		Permissions: []vanguard.PermissionTemplate{
+			{Level: Viewer, Resources: []string{"/books/{book}/pages/{page}"}, Methods: []string{"/books.BookService/Get*"}},
+		},
+	}
+
+	rr, err := vanguard.NewRoleRegistry(nil, owner, reader)
+	if err != nil {
+		t.Fatalf("unable to create role registry: %v", err)
+	}
+
+	perms, err := rr.Expand(
+		vanguard.RoleBinding{Role: "Book Owner", Params: map[string]string{"book": "1242"}},
+		vanguard.RoleBinding{Role: "Page Reader", Params: map[string]string{"book": "1", "page": "a/b*"}},
+	)
+	if err != nil {
+		t.Fatalf("unable to expand bindings: %v", err)
+	}
+
+	exp := []*vanguard.Permission{
+		{Level: Owner, Resources: []string{"/books/1242", "/books/1242/**"}},
+		{Level: Viewer, Resources: []string{"/books/1/pages/a%2Fb%2A"}, Methods: []string{"/books.BookService/Get*"}},
+	}
+	if len(perms) != len(exp) {
+		t.Fatalf("expected %d permissions, got: %v", len(exp), perms)
+	}
+	for i := range exp {
+		if !proto.Equal(perms[i], exp[i]) {
+			t.Errorf("permission mismatch, exp: %v, got: %v", exp[i], perms[i])
+		}
+	}
+
+	pf := rr.PermissionsFunc(func(context.Context) ([]vanguard.RoleBinding, error) {
+		return []vanguard.RoleBinding{{Role: "Book Owner", Params: map[string]string{"book": "1242"}}}, nil
+	})
+	if perms, err := pf(context.Background()); err != nil || len(perms) != 1 || !proto.Equal(perms[0], exp[0]) {
+		t.Errorf("permissions func mismatch, exp: %v, got: %v, err: %v", exp[:1], perms, err)
+	}
+
+	bindingErrs := []struct {
+		Name    string
+		Binding vanguard.RoleBinding
+	}{
+		{Name: "UnknownRole", Binding: vanguard.RoleBinding{Role: "Book Editor", Params: map[string]string{"book": "1"}}},
+		{Name: "MissingParam", Binding: vanguard.RoleBinding{Role: "Page Reader", Params: map[string]string{"book": "1"}}},
+		{Name: "UnknownParam", Binding: vanguard.RoleBinding{Role: "Book Owner", Params: map[string]string{"book": "1", "page": "2"}}},
+		{Name: "InvalidValue", Binding: vanguard.RoleBinding{Role: "Book Owner", Params: map[string]string{"book": ".."}}},
+	}
+	for _, tc := range bindingErrs {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := rr.Expand(tc.Binding); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	roleErrs := []struct {
+		Name  string
+		Rm    vanguard.ResourceMatcher
+		Roles []vanguard.Role
+	}{
+		{Name: "NoName", Roles: []vanguard.Role{{Permissions: owner.Permissions, Params: owner.Params}}},
+		{Name: "Duplicate", Roles: []vanguard.Role{owner, owner}},
+		{Name: "NoPermissions", Roles: []vanguard.Role{{Name: "Empty"}}},
+		{Name: "UndeclaredParam", Roles: []vanguard.Role{{Name: "Owner", Permissions: owner.Permissions}}},
+		{Name: "DuplicateParam", Roles: []vanguard.Role{{Name: "Owner", Params: []string{"book", "book"}, Permissions: owner.Permissions}}},
+		{Name: "Unterminated", Roles: []vanguard.Role{{Name: "Owner", Params: []string{"book"}, Permissions: []vanguard.PermissionTemplate{{Level: Owner, Resources: []string{"/books/{book"}}}}}},
+		{Name: "InvalidPattern", Rm: &vanguard.RegexResourceMatcher{}, Roles: []vanguard.Role{{Name: "Owner", Params: []string{"book"}, Permissions: []vanguard.PermissionTemplate{{Level: Owner, Resources: []string{"/books/{book}/(.*"}}}}}},
+		{Name: "InvalidMethod", Roles: []vanguard.Role{{Name: "Owner", Params: []string{"book"}, Permissions: []vanguard.PermissionTemplate{{Level: Owner, Resources: []string{"/books/{book}"}, Methods: []string{"["}}}}}},
+	}
+	for _, tc := range roleErrs {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := vanguard.NewRoleRegistry(tc.Rm, tc.Roles...); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	rm := &vanguard.RegexResourceMatcher{}
+	regex, err := vanguard.NewRoleRegistry(rm, vanguard.Role{
+		Name:        "Book Owner",
+		Params:      []string{"book"},
+		Permissions: []vanguard.PermissionTemplate{{Level: Owner, Resources: []string{"^/books/{book}$"}}},
+	})
+	if err != nil {
+		t.Fatalf("unable to create role registry: %v", err)
+	}
+
+	perms, err = regex.Expand(vanguard.RoleBinding{Role: "Book Owner", Params: map[string]string{"book": ".+"}})
+	if err != nil {
+		t.Fatalf("unable to expand bindings: %v", err)
+	}
+
+	if exp := `^/books/\.\+$`; len(perms) != 1 || perms[0].Resources[0] != exp {
+		t.Fatalf("expected the regex metacharacters to be quoted, exp: %s, got: %v", exp, perms)
+	}
+
+	if ok, _ := rm.MatchResource(perms[0].Resources[0], "/books/1"); ok {
+		t.Error("expected the expanded pattern to only match the bound book")
+	}
+
+}
+
+func TestGraphLevelMatcher(t *testing.T) {
+	const (
+		billingAdmin  = 20
+		billingViewer = 21
+	)
+
+	gm, err := vanguard.NewGraphLevelMatcher(map[int64][]int64{
+		Owner:        {Editor, billingAdmin},
+		Editor:       {Viewer},
+		billingAdmin: {billingViewer},
+	})
+	if err != nil {
+		t.Fatalf("unable to create matcher: %v", err)
+	}
+
+	testcases := []struct {
+		Has, Needs int64
+		Match      bool
+	}{
+		{Has: Owner, Needs: Owner, Match: true},
+		{Has: Owner, Needs: Editor, Match: true},
+		{Has: Owner, Needs: Viewer, Match: true},
+		{Has: Owner, Needs: billingViewer, Match: true},
+		{Has: billingAdmin, Needs: billingViewer, Match: true},
+		{Has: billingAdmin, Needs: Editor, Match: false},
+		{Has: Editor, Needs: billingViewer, Match: false},
+		{Has: Viewer, Needs: Editor, Match: false},
+		{Has: Manager, Needs: Manager, Match: true},
+		{Has: Manager, Needs: Viewer, Match: false},
+	}
+	for _, tc := range testcases {
+		if act := gm.MatchLevel(tc.Has, tc.Needs); act != tc.Match {
+			t.Errorf("match mismatch, has: %d, needs: %d, exp: %v, got: %v", tc.Has, tc.Needs, tc.Match, act)
+		}
+	}
+
+	cycles := []struct {
+		Name    string
+		Implies map[int64][]int64
+	}{
+		{Name: "Self", Implies: map[int64][]int64{Owner: {Owner}}},
+		{Name: "Direct", Implies: map[int64][]int64{Owner: {Editor}, Editor: {Owner}}},
+		{Name: "Transitive", Implies: map[int64][]int64{Owner: {Editor}, Editor: {Viewer}, Viewer: {Manager, Owner}}},
+	}
+	for _, tc := range cycles {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := vanguard.NewGraphLevelMatcher(tc.Implies); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := vanguard.NewLRUCache(2)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	// b is the least recently used
+	c.Add("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a to be cached, got: %v", v)
+	}
+
+	if c.Len() != 2 {
+		t.Fatalf("expected the size to be bounded, got: %d", c.Len())
+	}
+
+	c = vanguard.NewLRUCache(0)
+	c.Add("a", 1)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected the cache to be disabled")
+	}
+}
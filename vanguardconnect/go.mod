@@ -0,0 +1,24 @@
+module github.com/srikrsna/vanguard/vanguardconnect
+
+go 1.20
+
+require (
+	connectrpc.com/connect v1.16.2
+	github.com/srikrsna/vanguard v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto v0.0.0-20201102152239-715cce707fb0
+	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/antlr/antlr4 v0.0.0-20200503195918-621b933c7a7f // indirect
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/google/cel-go v0.7.3 // indirect
+	github.com/srikrsna/glob v0.0.0-20210617104638-066a22b56475 // indirect
+	github.com/stoewer/go-strcase v1.2.0 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/srikrsna/vanguard => ../
@@ -0,0 +1,124 @@
+// Package vanguardconnect enforces vanguard's asserts on connect-go handlers.
+package vanguardconnect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/srikrsna/vanguard"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewInterceptor returns a connect.Interceptor that asserts that a caller has permission to access the handlers,
+// with the same semantics and errors as vanguard's grpc interceptors. It is only applied on handlers,
+// calls made by clients are passed through.
+//
+// The procedure of the call is used as the method name to lookup the assert in store.
+// The Decision is attached to the context passed to the handlers, for streaming handlers it is available from it
+// once the first message is received and allowed, the same as the context of a grpc stream.
+func NewInterceptor(store vanguard.Vanguard, pf vanguard.PermissionsFunc, opt *vanguard.InterceptorOptions) connect.Interceptor {
+	return &interceptor{auth: vanguard.NewAuthorizer(store, pf, opt)}
+}
+
+type interceptor struct {
+	auth *vanguard.Authorizer
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		ctx, err := i.auth.Authorize(withCaller(ctx, req.Header(), req.Peer()), req.Spec().Procedure, req.Any())
+		if err != nil {
+			return nil, connectError(err)
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx = withCaller(ctx, conn.RequestHeader(), conn.Peer())
+		sa, err := i.auth.Stream(ctx, conn.Spec().Procedure, conn.Spec().StreamType&connect.StreamTypeClient != 0)
+		if err != nil {
+			return connectError(err)
+		} else if sa == nil {
+			return next(ctx, conn)
+		}
+
+		return next(sa.Context(ctx), &handlerConn{StreamingHandlerConn: conn, ctx: ctx, sa: sa})
+	}
+}
+
+// handlerConn asserts on the messages received on a stream
+type handlerConn struct {
+	connect.StreamingHandlerConn
+
+	ctx context.Context
+	sa  *vanguard.StreamAuthorizer
+}
+
+func (c *handlerConn) Receive(m interface{}) error {
+	if err := c.StreamingHandlerConn.Receive(m); err != nil {
+		return err
+	}
+
+	if _, err := c.sa.Authorize(c.ctx, m); err != nil {
+		return connectError(err)
+	}
+
+	return nil
+}
+
+// withCaller exposes the request headers to the asserts as `md`, the keys are lower cased as in grpc.
+// The address of the peer is exposed as `peer.address`, connect does not expose the tls state of the connection.
+func withCaller(ctx context.Context, h http.Header, p connect.Peer) context.Context {
+	md := make(metadata.MD, len(h))
+	for k, v := range h {
+		md[strings.ToLower(k)] = v
+	}
+
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr(p.Addr)})
+	return vanguard.WithMetadata(ctx, md)
+}
+
+// addr is the address of a connect peer
+type addr string
+
+func (a addr) Network() string { return "tcp" }
+func (a addr) String() string  { return string(a) }
+
+// connectError converts a grpc status error to a connect error, keeping its details
+func connectError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	cerr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, d := range st.Details() {
+		m, ok := d.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		if ed, err := connect.NewErrorDetail(m); err == nil {
+			cerr.AddDetail(ed)
+		}
+	}
+
+	return cerr
+}
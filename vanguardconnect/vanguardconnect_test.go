@@ -0,0 +1,89 @@
+package vanguardconnect_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	"github.com/srikrsna/vanguard/vanguardconnect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	Create = "/example.ExampleService/CreateExample"
+	Import = "/example.ExampleService/ImportExamples"
+)
+
+func TestInterceptor(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	in := vanguardconnect.NewInterceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: vanguard.LevelEditor, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}, nil)
+
+	mux := http.NewServeMux()
+	mux.Handle(Create, connect.NewUnaryHandler(Create, func(ctx context.Context, req *connect.Request[expb.CreateExampleRequest]) (*connect.Response[expb.Example], error) {
+		if _, ok := vanguard.DecisionFromContext(ctx); !ok {
+			return nil, errors.New("missing decision")
+		}
+		return connect.NewResponse(&expb.Example{}), nil
+	}, connect.WithInterceptors(in)))
+	mux.Handle(Import, connect.NewClientStreamHandler(Import, func(ctx context.Context, stream *connect.ClientStream[expb.CreateExampleRequest]) (*connect.Response[emptypb.Empty], error) {
+		if _, ok := vanguard.DecisionFromContext(ctx); ok {
+			return nil, errors.New("unexpected decision before the first message")
+		}
+		for stream.Receive() {
+			if _, ok := vanguard.DecisionFromContext(ctx); !ok {
+				return nil, errors.New("missing decision")
+			}
+		}
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}, connect.WithInterceptors(in)))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	create := connect.NewClient[expb.CreateExampleRequest, expb.Example](srv.Client(), srv.URL+Create)
+	if _, err := create.CallUnary(context.Background(), connect.NewRequest(&expb.CreateExampleRequest{Parent: "/parents/12422"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = create.CallUnary(context.Background(), connect.NewRequest(&expb.CreateExampleRequest{Parent: "/parents/12423"}))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission denied, got: %v", err)
+	}
+
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || len(cerr.Details()) != 1 {
+		t.Fatalf("expected error details, got: %v", err)
+	}
+
+	if v, err := cerr.Details()[0].Value(); err != nil {
+		t.Fatalf("unable to decode error detail: %v", err)
+	} else if _, ok := v.(*errdetails.ErrorInfo); !ok {
+		t.Fatalf("expected error info, got: %T", v)
+	}
+
+	imp := connect.NewClient[expb.CreateExampleRequest, emptypb.Empty](srv.Client(), srv.URL+Import)
+	stream := imp.CallClientStream(context.Background())
+	stream.Send(&expb.CreateExampleRequest{Parent: "/parents/12422"})
+	stream.Send(&expb.CreateExampleRequest{Parent: "/parents/12423"})
+	if _, err := stream.CloseAndReceive(); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected permission denied, got: %v", err)
+	}
+}
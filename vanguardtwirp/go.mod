@@ -0,0 +1,12 @@
+module github.com/srikrsna/vanguard/vanguardtwirp
+
+go 1.13
+
+require (
+	github.com/srikrsna/vanguard v0.0.0-00010101000000-000000000000
+	github.com/twitchtv/twirp v8.1.3+incompatible
+	google.golang.org/genproto v0.0.0-20201102152239-715cce707fb0
+	google.golang.org/grpc v1.33.2
+)
+
+replace github.com/srikrsna/vanguard => ../
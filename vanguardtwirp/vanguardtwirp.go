@@ -0,0 +1,125 @@
+// Package vanguardtwirp enforces vanguard's asserts on twirp servers.
+//
+// Twirp does not expose the http request to the interceptors, so the server must be wrapped with WithCaller
+// for the asserts to see the request headers as `md` and the caller as `peer`. Without it both are empty.
+package vanguardtwirp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/srikrsna/vanguard"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// NewInterceptor returns a twirp.Interceptor that asserts that a caller has permission to access the endpoints,
+// with the same semantics and errors as vanguard's grpc interceptors.
+// An interceptor is used instead of the twirp.ServerHooks, as the hooks do not have access to the request message.
+//
+// The method name is built from the package, service and method names in the context,
+// which are set by the twirp server. The Decision is attached to the context passed to the handler.
+//
+// The metadata of a google.rpc.ErrorInfo detail, if present, is added to the twirp error's meta.
+//
+// The server must be wrapped with WithCaller to expose the request headers and the caller to the asserts.
+func NewInterceptor(store vanguard.Vanguard, pf vanguard.PermissionsFunc, opt *vanguard.InterceptorOptions) twirp.Interceptor {
+	auth := vanguard.NewAuthorizer(store, pf, opt)
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, err := auth.Authorize(ctx, methodName(ctx), req)
+			if err != nil {
+				return nil, twirpError(err)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// WithCaller is a net/http middleware for twirp servers that exposes the request headers to the asserts as `md`,
+// with the keys lower cased as in grpc, and the remote address and tls state of the request as `peer`.
+//
+// Eg: http.Handle(server.PathPrefix(), vanguardtwirp.WithCaller(server))
+func WithCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := make(metadata.MD, len(r.Header))
+		for k, v := range r.Header {
+			md[strings.ToLower(k)] = v
+		}
+
+		p := &peer.Peer{Addr: addr(r.RemoteAddr)}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+		}
+
+		ctx := peer.NewContext(vanguard.WithMetadata(r.Context(), md), p)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// addr is the remote address of an http request
+type addr string
+
+func (a addr) Network() string { return "tcp" }
+func (a addr) String() string  { return string(a) }
+
+// methodName returns the fully qualified method name of the call, Eg: /package.Service/Method
+func methodName(ctx context.Context) string {
+	service, _ := twirp.ServiceName(ctx)
+	if pkg, _ := twirp.PackageName(ctx); pkg != "" {
+		service = pkg + "." + service
+	}
+
+	method, _ := twirp.MethodName(ctx)
+	return "/" + service + "/" + method
+}
+
+var errorCodes = map[codes.Code]twirp.ErrorCode{
+	codes.Canceled:           twirp.Canceled,
+	codes.Unknown:            twirp.Unknown,
+	codes.InvalidArgument:    twirp.InvalidArgument,
+	codes.DeadlineExceeded:   twirp.DeadlineExceeded,
+	codes.NotFound:           twirp.NotFound,
+	codes.AlreadyExists:      twirp.AlreadyExists,
+	codes.PermissionDenied:   twirp.PermissionDenied,
+	codes.ResourceExhausted:  twirp.ResourceExhausted,
+	codes.FailedPrecondition: twirp.FailedPrecondition,
+	codes.Aborted:            twirp.Aborted,
+	codes.OutOfRange:         twirp.OutOfRange,
+	codes.Unimplemented:      twirp.Unimplemented,
+	codes.Internal:           twirp.Internal,
+	codes.Unavailable:        twirp.Unavailable,
+	codes.DataLoss:           twirp.DataLoss,
+	codes.Unauthenticated:    twirp.Unauthenticated,
+}
+
+// twirpError converts a grpc status error to a twirp error
+func twirpError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	code, ok := errorCodes[st.Code()]
+	if !ok {
+		code = twirp.Unknown
+	}
+
+	terr := twirp.NewError(code, st.Message())
+	for _, d := range st.Details() {
+		if ei, ok := d.(*errdetails.ErrorInfo); ok {
+			for k, v := range ei.Metadata {
+				terr = terr.WithMeta(k, v)
+			}
+		}
+	}
+
+	return terr
+}
@@ -0,0 +1,145 @@
+package vanguardtwirp_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/srikrsna/vanguard"
+	expb "github.com/srikrsna/vanguard/example"
+	pb "github.com/srikrsna/vanguard/vanguard"
+	"github.com/srikrsna/vanguard/vanguardtwirp"
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+)
+
+func TestInterceptor(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	in := vanguardtwirp.NewInterceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return []*pb.Permission{
+			{Level: vanguard.LevelEditor, Resources: []string{"/parents/12422/**"}},
+		}, nil
+	}, nil)
+
+	method := in(func(ctx context.Context, req interface{}) (interface{}, error) {
+		if _, ok := vanguard.DecisionFromContext(ctx); !ok {
+			return nil, errors.New("missing decision")
+		}
+		return &expb.Example{}, nil
+	})
+
+	ctx := context.Background()
+	ctx = ctxsetters.WithPackageName(ctx, "example")
+	ctx = ctxsetters.WithServiceName(ctx, "ExampleService")
+	ctx = ctxsetters.WithMethodName(ctx, "CreateExample")
+
+	if _, err := method(ctx, &expb.CreateExampleRequest{Parent: "/parents/12422"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = method(ctx, &expb.CreateExampleRequest{Parent: "/parents/12423"})
+	terr, ok := err.(twirp.Error)
+	if !ok || terr.Code() != twirp.PermissionDenied {
+		t.Fatalf("expected permission denied, got: %v", err)
+	}
+
+	if terr.Meta("level") != "EDITOR" || terr.Meta("method") != "/example.ExampleService/CreateExample" {
+		t.Fatalf("expected the error info in meta, got: %v", terr.MetaMap())
+	}
+}
+
+func TestWithCaller(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	in := vanguardtwirp.NewInterceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}, nil)
+
+	method := in(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &expb.ListExamplesResponse{}, nil
+	})
+
+	for _, tc := range []struct {
+		Name   string
+		Tenant string
+		Err    bool
+	}{
+		{Name: "Allowed", Tenant: "1"},
+		{Name: "Denied", Tenant: "2", Err: true},
+	} {
+		var err error
+		h := vanguardtwirp.WithCaller(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ctxsetters.WithPackageName(r.Context(), "example")
+			ctx = ctxsetters.WithServiceName(ctx, "ExampleService")
+			ctx = ctxsetters.WithMethodName(ctx, "SearchExamples")
+			_, err = method(ctx, &expb.ListExamplesRequest{Parent: "tenants/1"})
+		}))
+
+		r := httptest.NewRequest(http.MethodPost, "/twirp/example.ExampleService/SearchExamples", nil)
+		r.Header.Set("X-Tenant-Id", tc.Tenant)
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: unexpected error: %v", tc.Name, err)
+		}
+	}
+}
+
+func TestWithCallerPeer(t *testing.T) {
+	store, err := vanguard.NewVanguard()
+	if err != nil {
+		t.Fatalf("unable to compile assertions: %v", err)
+	}
+
+	in := vanguardtwirp.NewInterceptor(store, func(context.Context) ([]*pb.Permission, error) {
+		return nil, nil
+	}, nil)
+
+	method := in(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &expb.ListExamplesResponse{}, nil
+	})
+
+	for _, tc := range []struct {
+		Name   string
+		Spiffe string
+		Err    bool
+	}{
+		{Name: "Insecure", Err: true},
+		{Name: "Spiffe", Spiffe: "spiffe://example.org/sync"},
+		{Name: "OtherSpiffe", Spiffe: "spiffe://example.org/billing", Err: true},
+	} {
+		var err error
+		h := vanguardtwirp.WithCaller(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ctxsetters.WithPackageName(r.Context(), "example")
+			ctx = ctxsetters.WithServiceName(ctx, "ExampleService")
+			ctx = ctxsetters.WithMethodName(ctx, "SyncExamples")
+			_, err = method(ctx, &expb.ListExamplesRequest{Parent: "tenants/1"})
+		}))
+
+		r := httptest.NewRequest(http.MethodPost, "/twirp/example.ExampleService/SyncExamples", nil)
+		if tc.Spiffe != "" {
+			u, perr := url.Parse(tc.Spiffe)
+			if perr != nil {
+				t.Fatalf("invalid uri: %v", perr)
+			}
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{URIs: []*url.URL{u}}}}}
+		}
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: unexpected error: %v", tc.Name, err)
+		}
+	}
+}